@@ -0,0 +1,257 @@
+package tsvreader
+
+import (
+	"time"
+)
+
+// Accessors with E suffix work like the corresponding accessors without
+// the suffix, but they return errors instead of setting Reader.Error.
+// The column isn't consumed on error, so it may be read again with another
+// accessor. For example, IntE may be followed by Float64E on the same column.
+
+// IntE returns the next int column value from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) IntE() (int, error) {
+	if tr.err != nil {
+		return 0, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.Int()
+	return v, tr.takeColError(pos)
+}
+
+// UintE returns the next uint column value from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) UintE() (uint, error) {
+	if tr.err != nil {
+		return 0, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.Uint()
+	return v, tr.takeColError(pos)
+}
+
+// Int8E returns the next int8 column value from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) Int8E() (int8, error) {
+	if tr.err != nil {
+		return 0, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.Int8()
+	return v, tr.takeColError(pos)
+}
+
+// Uint8E returns the next uint8 column value from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) Uint8E() (uint8, error) {
+	if tr.err != nil {
+		return 0, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.Uint8()
+	return v, tr.takeColError(pos)
+}
+
+// Int16E returns the next int16 column value from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) Int16E() (int16, error) {
+	if tr.err != nil {
+		return 0, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.Int16()
+	return v, tr.takeColError(pos)
+}
+
+// Uint16E returns the next uint16 column value from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) Uint16E() (uint16, error) {
+	if tr.err != nil {
+		return 0, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.Uint16()
+	return v, tr.takeColError(pos)
+}
+
+// Int32E returns the next int32 column value from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) Int32E() (int32, error) {
+	if tr.err != nil {
+		return 0, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.Int32()
+	return v, tr.takeColError(pos)
+}
+
+// Uint32E returns the next uint32 column value from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) Uint32E() (uint32, error) {
+	if tr.err != nil {
+		return 0, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.Uint32()
+	return v, tr.takeColError(pos)
+}
+
+// Int64E returns the next int64 column value from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) Int64E() (int64, error) {
+	if tr.err != nil {
+		return 0, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.Int64()
+	return v, tr.takeColError(pos)
+}
+
+// Uint64E returns the next uint64 column value from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) Uint64E() (uint64, error) {
+	if tr.err != nil {
+		return 0, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.Uint64()
+	return v, tr.takeColError(pos)
+}
+
+// Float32E returns the next float32 column value from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) Float32E() (float32, error) {
+	if tr.err != nil {
+		return 0, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.Float32()
+	return v, tr.takeColError(pos)
+}
+
+// Float64E returns the next float64 column value from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) Float64E() (float64, error) {
+	if tr.err != nil {
+		return 0, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.Float64()
+	return v, tr.takeColError(pos)
+}
+
+// DateE returns the next date column value from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) DateE() (time.Time, error) {
+	if tr.err != nil {
+		return zeroTime, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.Date()
+	return v, tr.takeColError(pos)
+}
+
+// DateTimeE returns the next datetime column value from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) DateTimeE() (time.Time, error) {
+	if tr.err != nil {
+		return zeroTime, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.DateTime()
+	return v, tr.takeColError(pos)
+}
+
+// CivilDateE returns the next date column value from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) CivilDateE() (Date, error) {
+	if tr.err != nil {
+		return Date{}, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.CivilDate()
+	return v, tr.takeColError(pos)
+}
+
+// TimeE returns the next time column value in the given layout from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) TimeE(layout string) (time.Time, error) {
+	if tr.err != nil {
+		return zeroTime, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.Time(layout)
+	return v, tr.takeColError(pos)
+}
+
+// UnixTimeE returns the next unix timestamp column value in the given unit from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) UnixTimeE(unit time.Duration) (time.Time, error) {
+	if tr.err != nil {
+		return zeroTime, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.UnixTime(unit)
+	return v, tr.takeColError(pos)
+}
+
+// DurationE returns the next duration column value in the given format from the current row.
+//
+// The returned error is a *ParseError. Error isn't set on failure.
+func (tr *Reader) DurationE(format DurationFormat) (time.Duration, error) {
+	if tr.err != nil {
+		return 0, tr.err
+	}
+	pos := tr.colPos()
+	v := tr.Duration(format)
+	return v, tr.takeColError(pos)
+}
+
+// readerColPos is the position of the next column in the current row.
+type readerColPos struct {
+	b       []byte
+	col     int
+	projPos int
+}
+
+func (tr *Reader) colPos() readerColPos {
+	return readerColPos{
+		b:       tr.b,
+		col:     tr.col,
+		projPos: tr.projPos,
+	}
+}
+
+// takeColError resets the column error set after pos and returns it.
+//
+// The position of the next column is restored to pos on error.
+func (tr *Reader) takeColError(pos readerColPos) error {
+	err := tr.err
+	if err == nil {
+		return nil
+	}
+	tr.err = nil
+	tr.rowErr = false
+	tr.b = pos.b
+	tr.col = pos.col
+	tr.projPos = pos.projPos
+	return err
+}
@@ -0,0 +1,103 @@
+package tsvreader
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestReaderIntEFallback(t *testing.T) {
+	b := bytes.NewBufferString("42\t1.5\tfoo\n")
+	r := New(b)
+	r.Next()
+
+	var fs []float64
+	for r.HasCols() {
+		n, err := r.IntE()
+		if err == nil {
+			fs = append(fs, float64(n))
+			continue
+		}
+		var pe *ParseError
+		if !errors.As(err, &pe) || pe.Type != "int" {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		f, err := r.Float64E()
+		if err == nil {
+			fs = append(fs, f)
+			continue
+		}
+		if !errors.Is(err, strconv.ErrSyntax) {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if s := r.String(); s != "foo" {
+			t.Fatalf("unexpected string: %q. Expecting %q", s, "foo")
+		}
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(fs) != 2 || fs[0] != 42 || fs[1] != 1.5 {
+		t.Fatalf("unexpected values: %v. Expecting [42 1.5]", fs)
+	}
+	if r.Next() {
+		t.Fatalf("Next must return false")
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestReaderDateE(t *testing.T) {
+	b := bytes.NewBufferString("2017-10-13\n")
+	r := New(b)
+	r.Next()
+	if _, err := r.DateTimeE(); err == nil {
+		t.Fatalf("expecting non-nil error")
+	}
+	if r.Error() != nil {
+		t.Fatalf("unexpected error: %s", r.Error())
+	}
+	d, err := r.DateE()
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !d.Equal(time.Date(2017, 10, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected date: %s", d)
+	}
+
+	// Reading past the last column must fail without setting Error.
+	if _, err := r.Int64E(); !errors.Is(err, ErrNoMoreColumns) {
+		t.Fatalf("expecting ErrNoMoreColumns; got %v", err)
+	}
+	if r.Error() != nil {
+		t.Fatalf("unexpected error: %s", r.Error())
+	}
+	if r.Next() {
+		t.Fatalf("Next must return false")
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestReaderIntEProjection(t *testing.T) {
+	b := bytes.NewBufferString("a\tb\t1.5\n")
+	r := New(b)
+	r.Projection = []int{2, 0}
+	r.Next()
+	if _, err := r.Uint8E(); err == nil {
+		t.Fatalf("expecting non-nil error")
+	}
+	if f, err := r.Float32E(); err != nil || f != 1.5 {
+		t.Fatalf("unexpected result: %v, %v. Expecting 1.5, nil", f, err)
+	}
+	if s := r.String(); s != "a" {
+		t.Fatalf("unexpected string: %q. Expecting %q", s, "a")
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
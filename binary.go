@@ -0,0 +1,80 @@
+package tsvreader
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+)
+
+// AppendHex appends the hex-decoded next column value from the current row to dst
+// and returns the extended dst.
+//
+// The column may contain upper-case and lower-case hex digits such as
+// the output of ClickHouse hex function. dst is returned unchanged on error.
+func (tr *Reader) AppendHex(dst []byte) []byte {
+	if tr.err != nil {
+		return dst
+	}
+	b, err := tr.nextCol()
+	if err != nil {
+		tr.setColError("cannot read", "hex", nil, err)
+		return dst
+	}
+	dstLen := len(dst)
+	dst, err = hex.AppendDecode(dst, b)
+	if err != nil {
+		tr.setColError("cannot parse", "hex", b, err)
+		return dst[:dstLen]
+	}
+	return dst
+}
+
+// AppendBase64 appends the base64-decoded next column value from the current row
+// to dst and returns the extended dst.
+//
+// The column must contain padded standard base64 such as the output
+// of ClickHouse base64Encode function. dst is returned unchanged on error.
+func (tr *Reader) AppendBase64(dst []byte) []byte {
+	if tr.err != nil {
+		return dst
+	}
+	b, err := tr.nextCol()
+	if err != nil {
+		tr.setColError("cannot read", "base64", nil, err)
+		return dst
+	}
+	dstLen := len(dst)
+	dst, err = base64.StdEncoding.AppendDecode(dst, b)
+	if err != nil {
+		tr.setColError("cannot parse", "base64", b, err)
+		return dst[:dstLen]
+	}
+	return dst
+}
+
+// FixedString returns the next FixedString(n) column value from the current row.
+//
+// The unescaped column must contain exactly n bytes. Trailing zero bytes,
+// which are used by ClickHouse for padding short values, are trimmed.
+//
+// The returned value is valid until the next call to Reader.
+func (tr *Reader) FixedString(n int) []byte {
+	if tr.err != nil {
+		return nil
+	}
+	b, err := tr.nextCol()
+	if err != nil {
+		tr.setColError("cannot read", "fixedstring", nil, err)
+		return nil
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
+		return b
+	}
+	s := tr.unescapeCol(b)
+	if len(s) != n {
+		tr.setColError("cannot parse", "fixedstring", b, fmt.Errorf("unexpected length: %d bytes. Expecting %d bytes", len(s), n))
+		return nil
+	}
+	return bytes.TrimRight(s, "\x00")
+}
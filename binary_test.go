@@ -0,0 +1,132 @@
+package tsvreader
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReaderAppendHex(t *testing.T) {
+	b := bytes.NewBufferString("48656c6C6f\t\tzz\n")
+	r := New(b)
+	r.Next()
+	dst := r.AppendHex([]byte("x"))
+	if string(dst) != "xHello" {
+		t.Fatalf("unexpected result: %q. Expecting %q", dst, "xHello")
+	}
+	dst = r.AppendHex(dst)
+	if string(dst) != "xHello" {
+		t.Fatalf("unexpected result: %q. Expecting %q", dst, "xHello")
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	dst = r.AppendHex(dst)
+	if string(dst) != "xHello" {
+		t.Fatalf("dst must remain unchanged on error; got %q", dst)
+	}
+	err := r.Error()
+	var ie hex.InvalidByteError
+	if !errors.As(err, &ie) {
+		t.Fatalf("expecting hex.InvalidByteError; got %v", err)
+	}
+	if errS := err.Error(); !strings.Contains(errS, "cannot parse `hex` at row #1, col #3") {
+		t.Fatalf("unexpected error: %s", errS)
+	}
+}
+
+func TestReaderAppendHexOddLength(t *testing.T) {
+	b := bytes.NewBufferString("abc\n")
+	r := New(b)
+	r.Next()
+	if dst := r.AppendHex(nil); len(dst) != 0 {
+		t.Fatalf("unexpected non-empty result: %q", dst)
+	}
+	if err := r.Error(); !errors.Is(err, hex.ErrLength) {
+		t.Fatalf("expecting hex.ErrLength; got %v", err)
+	}
+}
+
+func TestReaderAppendBase64(t *testing.T) {
+	b := bytes.NewBufferString("SGVsbG8=\t\t+/8=\tSGVsbG8\n")
+	r := New(b)
+	r.Next()
+	dst := r.AppendBase64(nil)
+	if string(dst) != "Hello" {
+		t.Fatalf("unexpected result: %q. Expecting %q", dst, "Hello")
+	}
+	dst = r.AppendBase64(dst[:0])
+	if len(dst) != 0 {
+		t.Fatalf("unexpected non-empty result: %q", dst)
+	}
+	dst = r.AppendBase64(dst)
+	if string(dst) != "\xfb\xff" {
+		t.Fatalf("unexpected result: %q. Expecting %q", dst, "\xfb\xff")
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	dst = r.AppendBase64(dst)
+	if string(dst) != "\xfb\xff" {
+		t.Fatalf("dst must remain unchanged on error; got %q", dst)
+	}
+	if errS := r.Error().Error(); !strings.Contains(errS, "cannot parse `base64` at row #1, col #4") {
+		t.Fatalf("unexpected error: %s", errS)
+	}
+}
+
+func TestReaderFixedString(t *testing.T) {
+	b := bytes.NewBufferString("ab\\0\\0\tabcd\ta\\tb\\0\\0c\\0\tab\n")
+	r := New(b)
+	r.Next()
+	if s := r.FixedString(4); string(s) != "ab" {
+		t.Fatalf("unexpected value: %q. Expecting %q", s, "ab")
+	}
+	if s := r.FixedString(4); string(s) != "abcd" {
+		t.Fatalf("unexpected value: %q. Expecting %q", s, "abcd")
+	}
+	if s := r.FixedString(7); string(s) != "a\tb\x00\x00c" {
+		t.Fatalf("unexpected value: %q. Expecting %q", s, "a\tb\x00\x00c")
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if rawRow := r.RawRow(); string(rawRow) != "ab\\0\\0\tabcd\ta\\tb\\0\\0c\\0\tab" {
+		t.Fatalf("FixedString mustn't modify the row; got %q", rawRow)
+	}
+
+	if s := r.FixedString(4); s != nil {
+		t.Fatalf("unexpected non-nil value: %q", s)
+	}
+	expectedS := "cannot parse `fixedstring` at row #1, col #4"
+	if errS := r.Error().Error(); !strings.Contains(errS, expectedS) {
+		t.Fatalf("unexpected error: %s. Must contain %q", errS, expectedS)
+	}
+}
+
+func TestReaderBinaryNoAllocs(t *testing.T) {
+	data := []byte("48656c6c6f\tSGVsbG8=\tab\\0\\0\n")
+	br := bytes.NewReader(data)
+	r := New(br)
+	var dst []byte
+	n := testing.AllocsPerRun(100, func() {
+		br.Reset(data)
+		r.Reset(br)
+		r.Next()
+		dst = r.AppendHex(dst[:0])
+		dst = r.AppendBase64(dst)
+		if string(dst) != "HelloHello" {
+			panic("unexpected value")
+		}
+		if string(r.FixedString(4)) != "ab" {
+			panic("unexpected fixed string")
+		}
+	})
+	if n != 0 {
+		t.Fatalf("unexpected number of allocations: %v. Expecting 0", n)
+	}
+}
@@ -0,0 +1,95 @@
+package tsvreader
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Date is a calendar date without time of day and location.
+//
+// Date occupies 4 bytes, so it is more compact than time.Time.
+// The zero Date corresponds to 0000-00-00, which is used by ClickHouse
+// for zero dates.
+type Date struct {
+	Year  uint16
+	Month uint8
+	Day   uint8
+}
+
+// DateOf returns the Date for t in the location of t.
+//
+// An error is returned if the year of t is outside the range [0..65535],
+// which may be represented by Date.
+//
+// The zero time.Time results in 0001-01-01, not in the zero Date.
+// So DateOf(d.Time()) returns 0001-01-01 for the zero Date d.
+func DateOf(t time.Time) (Date, error) {
+	y, m, d := t.Date()
+	if y < 0 || y > math.MaxUint16 {
+		return Date{}, fmt.Errorf("year out of range: %d; it must be in the range [0..%d]", y, math.MaxUint16)
+	}
+	return Date{
+		Year:  uint16(y),
+		Month: uint8(m),
+		Day:   uint8(d),
+	}, nil
+}
+
+// IsZero returns true if d is 0000-00-00.
+func (d Date) IsZero() bool {
+	return d == Date{}
+}
+
+// Time returns midnight UTC for d.
+//
+// The zero time.Time is returned for the zero Date.
+func (d Date) Time() time.Time {
+	if d.IsZero() {
+		return zeroTime
+	}
+	return time.Date(int(d.Year), time.Month(d.Month), int(d.Day), 0, 0, 0, 0, time.UTC)
+}
+
+// String returns d in the format YYYY-MM-DD.
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// CivilDate returns the next date column value from the current row.
+//
+// date must be in the format YYYY-MM-DD. Unlike Date, CivilDate returns
+// a 4-byte value without location. 0000-00-00 results in the zero Date,
+// which is distinct from 0001-01-01, while Date returns the zero time.Time
+// for both of them. Invalid dates such as 2023-02-31 are rejected even if
+// StrictDates isn't set.
+func (tr *Reader) CivilDate() Date {
+	if tr.err != nil {
+		return Date{}
+	}
+	b, err := tr.nextCol()
+	if err != nil {
+		tr.setColError("cannot read", "date", nil, err)
+		return Date{}
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
+		return Date{}
+	}
+	s := b2s(b)
+
+	y, m, d, err := parseDate(s, tr.StrictDates)
+	if err == nil {
+		// Always validate the date regardless of StrictDates,
+		// since Date cannot normalize invalid dates such as 2023-02-31.
+		err = checkDate(y, m, d)
+	}
+	if err != nil {
+		tr.setColError("cannot parse", "date", b, err)
+		return Date{}
+	}
+	return Date{
+		Year:  uint16(y),
+		Month: uint8(m),
+		Day:   uint8(d),
+	}
+}
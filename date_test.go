@@ -0,0 +1,170 @@
+package tsvreader
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unsafe"
+)
+
+func TestDateSize(t *testing.T) {
+	if n := unsafe.Sizeof(Date{}); n != 4 {
+		t.Fatalf("unexpected Date size: %d bytes. Expecting 4 bytes", n)
+	}
+}
+
+func TestReaderCivilDateSuccess(t *testing.T) {
+	testReaderCivilDateSuccess(t, "0000-00-00")
+	testReaderCivilDateSuccess(t, "1970-01-01")
+	testReaderCivilDateSuccess(t, "2017-10-13")
+	testReaderCivilDateSuccess(t, "9999-12-31")
+	testReaderCivilDateSuccess(t, "2024-02-29")
+}
+
+func testReaderCivilDateSuccess(t *testing.T, date string) {
+	t.Helper()
+
+	r := New(bytes.NewBufferString(date + "\n"))
+	r.Next()
+	d := r.CivilDate()
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error on date %q: %s", date, err)
+	}
+	if s := d.String(); s != date {
+		t.Fatalf("unexpected date: %q. Expecting %q", s, date)
+	}
+	if date == "0000-00-00" {
+		if !d.IsZero() {
+			t.Fatalf("expecting zero date for %q", date)
+		}
+		if !d.Time().IsZero() {
+			t.Fatalf("expecting zero time for %q; got %s", date, d.Time())
+		}
+		return
+	}
+	tm := d.Time()
+	if s := tm.Format("2006-01-02"); s != date {
+		t.Fatalf("unexpected time: %q. Expecting %q", s, date)
+	}
+	if tm.Location() != time.UTC {
+		t.Fatalf("unexpected location: %s. Expecting UTC", tm.Location())
+	}
+	d1, err := DateOf(tm)
+	if err != nil {
+		t.Fatalf("unexpected DateOf error for %q: %s", date, err)
+	}
+	if d1 != d {
+		t.Fatalf("unexpected DateOf result: %s. Expecting %s", d1, d)
+	}
+}
+
+func TestReaderCivilDateFailure(t *testing.T) {
+	testReaderCivilDateFailure(t, "")
+	testReaderCivilDateFailure(t, "foobar")
+	testReaderCivilDateFailure(t, "2017-10-1")
+	testReaderCivilDateFailure(t, "2017-bb-aa")
+	testReaderCivilDateFailure(t, "2017-13-01")
+	testReaderCivilDateFailure(t, "2017--1-01")
+	testReaderCivilDateFailure(t, "2023-02-31")
+	testReaderCivilDateFailure(t, "2023-02-29")
+	testReaderCivilDateFailure(t, "2017-04-31")
+	testReaderCivilDateFailure(t, "2017-00-10")
+	testReaderCivilDateFailure(t, "2017-10-00")
+}
+
+func testReaderCivilDateFailure(t *testing.T, date string) {
+	t.Helper()
+
+	r := New(bytes.NewBufferString(date + "\n"))
+	r.Next()
+	d := r.CivilDate()
+	if !d.IsZero() {
+		t.Fatalf("unexpected non-zero date when parsing %q: %s", date, d)
+	}
+	err := r.Error()
+	if err == nil {
+		t.Fatalf("expecting non-nil error when parsing %q", date)
+	}
+	if errS := err.Error(); !strings.Contains(errS, "cannot parse `date`") {
+		t.Fatalf("unexpected error: %s. Must contain %q", errS, "cannot parse `date`")
+	}
+}
+
+func TestDateOfZeroTime(t *testing.T) {
+	d, err := DateOf(time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if d != (Date{Year: 1, Month: 1, Day: 1}) {
+		t.Fatalf("unexpected date: %s. Expecting 0001-01-01", d)
+	}
+}
+
+func TestDateOfOutOfRange(t *testing.T) {
+	f := func(y int) {
+		t.Helper()
+
+		d, err := DateOf(time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC))
+		if err == nil {
+			t.Fatalf("expecting non-nil error for year %d; got %s", y, d)
+		}
+		if !d.IsZero() {
+			t.Fatalf("expecting zero date for year %d; got %s", y, d)
+		}
+	}
+
+	f(-5)
+	f(-1)
+	f(65536)
+	f(70000)
+
+	d, err := DateOf(time.Date(65535, 12, 31, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if s := d.String(); s != "65535-12-31" {
+		t.Fatalf("unexpected date: %s. Expecting 65535-12-31", s)
+	}
+}
+
+func TestReaderCivilDateZero(t *testing.T) {
+	r := New(bytes.NewBufferString("0000-00-00\t0001-01-01\t0000-00-00\t0001-01-01\n"))
+	r.Next()
+	d1 := r.CivilDate()
+	d2 := r.CivilDate()
+	if !d1.IsZero() {
+		t.Fatalf("expecting zero date; got %s", d1)
+	}
+	if d2 != (Date{Year: 1, Month: 1, Day: 1}) {
+		t.Fatalf("unexpected date: %s. Expecting 0001-01-01", d2)
+	}
+
+	// Date cannot distinguish between 0000-00-00 and 0001-01-01.
+	if tm := r.Date(); !tm.IsZero() {
+		t.Fatalf("expecting zero time; got %s", tm)
+	}
+	if tm := r.Date(); !tm.IsZero() {
+		t.Fatalf("expecting zero time; got %s", tm)
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestReaderCivilDateNoAllocs(t *testing.T) {
+	data := []byte("2017-10-13\n")
+	br := bytes.NewReader(data)
+	r := New(br)
+	n := testing.AllocsPerRun(100, func() {
+		br.Reset(data)
+		r.Reset(br)
+		r.Next()
+		if r.CivilDate().IsZero() {
+			panic("unexpected zero date")
+		}
+	})
+	if n != 0 {
+		t.Fatalf("unexpected number of allocations: %v. Expecting 0", n)
+	}
+}
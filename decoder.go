@@ -0,0 +1,67 @@
+package tsvreader
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// DecodeFunc decodes the unescaped column value b into v.
+//
+// v is a pointer to the type the DecodeFunc is registered for.
+// DecodeFunc must not retain b after returning.
+type DecodeFunc func(v any, b []byte) error
+
+// RegisterDecoder registers f for decoding columns into values of type t.
+//
+// Reader.Scan uses f for destinations of type *t. This allows decoding
+// custom types without intermediate string allocations.
+//
+// Registered decoders are shared by all the Readers in the program,
+// so RegisterDecoder panics if t is natively supported by Scan, such as int,
+// string or time.Time. Use Reader.Decoders for overriding the built-in
+// parsing for a particular Reader.
+//
+// RegisterDecoder is usually called from init. It is safe calling it
+// from concurrently running goroutines.
+func RegisterDecoder(t reflect.Type, f DecodeFunc) {
+	if builtinTypes[t] {
+		panic(fmt.Errorf("BUG: cannot register decoder for built-in type %s; use Reader.Decoders instead", t))
+	}
+	decoders.Store(t, f)
+}
+
+// lookupDecoder returns the decoder registered for the type pt points to.
+func lookupDecoder(pt reflect.Type) DecodeFunc {
+	if pt == nil || pt.Kind() != reflect.Ptr {
+		return nil
+	}
+	f, ok := decoders.Load(pt.Elem())
+	if !ok {
+		return nil
+	}
+	return f.(DecodeFunc)
+}
+
+var decoders sync.Map
+
+// builtinTypes contains types natively supported by Reader.Scan.
+var builtinTypes = map[reflect.Type]bool{
+	reflect.TypeOf(int(0)):      true,
+	reflect.TypeOf(uint(0)):     true,
+	reflect.TypeOf(int8(0)):     true,
+	reflect.TypeOf(uint8(0)):    true,
+	reflect.TypeOf(int16(0)):    true,
+	reflect.TypeOf(uint16(0)):   true,
+	reflect.TypeOf(int32(0)):    true,
+	reflect.TypeOf(uint32(0)):   true,
+	reflect.TypeOf(int64(0)):    true,
+	reflect.TypeOf(uint64(0)):   true,
+	reflect.TypeOf(float32(0)):  true,
+	reflect.TypeOf(float64(0)):  true,
+	reflect.TypeOf(""):          true,
+	reflect.TypeOf([]byte(nil)): true,
+	reflect.TypeOf(time.Time{}): true,
+	reflect.TypeOf(Date{}):      true,
+}
@@ -0,0 +1,135 @@
+package tsvreader
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testMoney int64
+
+func init() {
+	RegisterDecoder(reflect.TypeOf(testMoney(0)), func(v any, b []byte) error {
+		var units, cents int64
+		if _, err := fmt.Sscanf(string(b), "%d.%02d", &units, &cents); err != nil {
+			return err
+		}
+		*v.(*testMoney) = testMoney(units*100 + cents)
+		return nil
+	})
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	r := New(bytes.NewBufferString("12.34\tfoo\n"))
+	r.Next()
+	var m testMoney
+	var s string
+	if err := r.Scan(&m, &s); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if m != 1234 {
+		t.Fatalf("unexpected value: %d. Expecting 1234", m)
+	}
+	if s != "foo" {
+		t.Fatalf("unexpected string: %q. Expecting %q", s, "foo")
+	}
+
+	// Decoding error.
+	r.Reset(bytes.NewBufferString("foo\n"))
+	r.Next()
+	err := r.Scan(&m)
+	if err == nil {
+		t.Fatalf("expecting non-nil error")
+	}
+	expectedS := "cannot decode `tsvreader.testMoney` at row #1, col #1"
+	if errS := err.Error(); !strings.Contains(errS, expectedS) {
+		t.Fatalf("unexpected error: %s. Must contain %q", errS, expectedS)
+	}
+}
+
+func TestRegisterDecoderBuiltinType(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expecting panic when registering decoder for built-in type")
+		}
+	}()
+	RegisterDecoder(reflect.TypeOf(time.Time{}), func(v any, b []byte) error {
+		return nil
+	})
+}
+
+func TestReaderDecoders(t *testing.T) {
+	data := "13.10.2017\t2017-10-13\n"
+	r := New(bytes.NewBufferString(data))
+	r.Decoders = map[reflect.Type]DecodeFunc{
+		reflect.TypeOf(time.Time{}): func(v any, b []byte) error {
+			tm, err := time.Parse("02.01.2006", string(b))
+			if err != nil {
+				return err
+			}
+			*v.(*time.Time) = tm
+			return nil
+		},
+	}
+	r.Next()
+	var tm time.Time
+	if err := r.Scan(&tm); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := time.Date(2017, 10, 13, 0, 0, 0, 0, time.UTC); !tm.Equal(expected) {
+		t.Fatalf("unexpected time: %s. Expecting %s", tm, expected)
+	}
+
+	// The decoder must be used instead of the built-in parser.
+	err := r.Scan(&tm)
+	expectedS := "cannot decode `time.Time` at row #1, col #2"
+	if err == nil || !strings.Contains(err.Error(), expectedS) {
+		t.Fatalf("unexpected error: %v. Must contain %q", err, expectedS)
+	}
+
+	// Other Readers mustn't be affected.
+	r2 := New(bytes.NewBufferString(data))
+	r2.Next()
+	r2.SkipCol()
+	if err := r2.Scan(&tm); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := time.Date(2017, 10, 13, 0, 0, 0, 0, time.UTC); !tm.Equal(expected) {
+		t.Fatalf("unexpected time: %s. Expecting %s", tm, expected)
+	}
+}
+
+func TestRegisterDecoderEmptyAsDefault(t *testing.T) {
+	r := New(bytes.NewBufferString("\t12.34\n"))
+	r.EmptyAsDefault = true
+	r.Next()
+	var m1, m2 testMoney
+	if err := r.Scan(&m1, &m2); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if m1 != 0 {
+		t.Fatalf("unexpected value: %d. Expecting 0", m1)
+	}
+	if m2 != 1234 {
+		t.Fatalf("unexpected value: %d. Expecting 1234", m2)
+	}
+}
+
+func TestRegisterDecoderEmptyAsDefaultReuse(t *testing.T) {
+	r := New(bytes.NewBufferString("0.10\n\n"))
+	r.EmptyAsDefault = true
+	var m testMoney
+	var ms []testMoney
+	for r.Next() {
+		if err := r.Scan(&m); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		ms = append(ms, m)
+	}
+	if s := fmt.Sprintf("%v", ms); s != "[10 0]" {
+		t.Fatalf("unexpected values: %s. Expecting [10 0]", s)
+	}
+}
@@ -0,0 +1,141 @@
+package tsvreader
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DurationFormat is the format of duration columns read by Reader.Duration.
+type DurationFormat int
+
+const (
+	// DurationGo is the format accepted by time.ParseDuration
+	// such as 1h2m3.5s.
+	DurationGo DurationFormat = iota
+
+	// DurationSeconds is the number of seconds with optional fraction
+	// such as 3723.5.
+	DurationSeconds
+
+	// DurationInterval is ClickHouse interval text such as 5 SECOND
+	// or INTERVAL 2 HOURS.
+	//
+	// The INTERVAL prefix is optional. Units are case-insensitive and may be
+	// plural. Supported units are NANOSECOND, MICROSECOND, MILLISECOND,
+	// SECOND, MINUTE, HOUR, DAY and WEEK. MONTH, QUARTER and YEAR
+	// aren't supported, since they have no fixed duration.
+	DurationInterval
+)
+
+// Duration returns the next duration column value in the given format from the current row.
+func (tr *Reader) Duration(format DurationFormat) time.Duration {
+	if tr.err != nil {
+		return 0
+	}
+	b, err := tr.nextCol()
+	if err != nil {
+		tr.setColError("cannot read", "duration", nil, err)
+		return 0
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
+		return 0
+	}
+	s := b2s(b)
+
+	d, err := parseDuration(s, format)
+	if err != nil {
+		tr.setColError("cannot parse", "duration", b, err)
+		return 0
+	}
+	return d
+}
+
+func parseDuration(s string, format DurationFormat) (time.Duration, error) {
+	switch format {
+	case DurationGo:
+		return time.ParseDuration(s)
+	case DurationSeconds:
+		return parseSecondsDuration(s)
+	case DurationInterval:
+		return parseIntervalDuration(s)
+	default:
+		return 0, fmt.Errorf("unsupported duration format %d", format)
+	}
+}
+
+func parseSecondsDuration(s string) (time.Duration, error) {
+	intS, fracS, hasFrac := strings.Cut(s, ".")
+	if hasFrac && (len(fracS) == 0 || !isDigits(fracS)) {
+		return 0, strconv.ErrSyntax
+	}
+	n, err := parseInt64(intS)
+	if err != nil {
+		return 0, err
+	}
+	d, err := mulDuration(n, time.Second)
+	if err != nil {
+		return 0, err
+	}
+	if !hasFrac {
+		return d, nil
+	}
+	nsec := time.Duration(parseNanos(fracS))
+	if intS[0] == '-' {
+		if d < math.MinInt64+nsec {
+			return 0, strconv.ErrRange
+		}
+		return d - nsec, nil
+	}
+	if d > math.MaxInt64-nsec {
+		return 0, strconv.ErrRange
+	}
+	return d + nsec, nil
+}
+
+var intervalUnits = []struct {
+	name string
+	unit time.Duration
+}{
+	{"NANOSECOND", time.Nanosecond},
+	{"MICROSECOND", time.Microsecond},
+	{"MILLISECOND", time.Millisecond},
+	{"SECOND", time.Second},
+	{"MINUTE", time.Minute},
+	{"HOUR", time.Hour},
+	{"DAY", 24 * time.Hour},
+	{"WEEK", 7 * 24 * time.Hour},
+}
+
+func parseIntervalDuration(s string) (time.Duration, error) {
+	if len(s) > len("INTERVAL ") && strings.EqualFold(s[:len("INTERVAL ")], "INTERVAL ") {
+		s = s[len("INTERVAL "):]
+	}
+	nS, unitS, ok := strings.Cut(s, " ")
+	if !ok {
+		return 0, fmt.Errorf("missing interval unit")
+	}
+	n, err := parseInt64(nS)
+	if err != nil {
+		return 0, err
+	}
+	if len(unitS) > 1 && (unitS[len(unitS)-1] == 's' || unitS[len(unitS)-1] == 'S') {
+		unitS = unitS[:len(unitS)-1]
+	}
+	for _, iu := range intervalUnits {
+		if strings.EqualFold(unitS, iu.name) {
+			return mulDuration(n, iu.unit)
+		}
+	}
+	return 0, fmt.Errorf("unsupported interval unit %q", unitS)
+}
+
+// mulDuration returns n*unit or strconv.ErrRange on overflow.
+func mulDuration(n int64, unit time.Duration) (time.Duration, error) {
+	if n > int64(math.MaxInt64/unit) || n < int64(math.MinInt64/unit) {
+		return 0, strconv.ErrRange
+	}
+	return time.Duration(n) * unit, nil
+}
@@ -0,0 +1,159 @@
+package tsvreader
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReaderDurationSuccess(t *testing.T) {
+	f := func(format DurationFormat, s string, expected time.Duration) {
+		t.Helper()
+
+		r := New(bytes.NewBufferString(s + "\n"))
+		r.Next()
+		d := r.Duration(format)
+		if err := r.Error(); err != nil {
+			t.Fatalf("unexpected error when parsing %q in format %d: %s", s, format, err)
+		}
+		if d != expected {
+			t.Fatalf("unexpected duration when parsing %q in format %d: %s. Expecting %s", s, format, d, expected)
+		}
+	}
+
+	f(DurationGo, "0", 0)
+	f(DurationGo, "1h2m3s", time.Hour+2*time.Minute+3*time.Second)
+	f(DurationGo, "-1.5s", -1500*time.Millisecond)
+	f(DurationGo, "300ms", 300*time.Millisecond)
+
+	f(DurationSeconds, "0", 0)
+	f(DurationSeconds, "3723", time.Hour+2*time.Minute+3*time.Second)
+	f(DurationSeconds, "1.5", 1500*time.Millisecond)
+	f(DurationSeconds, "0.000000001", time.Nanosecond)
+	f(DurationSeconds, "0.0000000019", time.Nanosecond)
+	f(DurationSeconds, "-0.5", -500*time.Millisecond)
+	f(DurationSeconds, "-2.25", -2250*time.Millisecond)
+	f(DurationSeconds, "9223372036.854775807", time.Duration(1<<63-1))
+
+	f(DurationInterval, "5 SECOND", 5*time.Second)
+	f(DurationInterval, "5 second", 5*time.Second)
+	f(DurationInterval, "5 Seconds", 5*time.Second)
+	f(DurationInterval, "INTERVAL 2 HOUR", 2*time.Hour)
+	f(DurationInterval, "interval 2 hours", 2*time.Hour)
+	f(DurationInterval, "-3 MINUTE", -3*time.Minute)
+	f(DurationInterval, "10 NANOSECOND", 10*time.Nanosecond)
+	f(DurationInterval, "10 MICROSECONDS", 10*time.Microsecond)
+	f(DurationInterval, "10 MILLISECOND", 10*time.Millisecond)
+	f(DurationInterval, "1 DAY", 24*time.Hour)
+	f(DurationInterval, "2 WEEKS", 14*24*time.Hour)
+}
+
+func TestReaderDurationFailure(t *testing.T) {
+	f := func(format DurationFormat, s string) {
+		t.Helper()
+
+		r := New(bytes.NewBufferString(s + "\n"))
+		r.Next()
+		d := r.Duration(format)
+		if d != 0 {
+			t.Fatalf("expecting zero duration when parsing %q in format %d; got %s", s, format, d)
+		}
+		err := r.Error()
+		if err == nil {
+			t.Fatalf("expecting non-nil error when parsing %q in format %d", s, format)
+		}
+		if errS := err.Error(); !strings.Contains(errS, "cannot parse `duration`") {
+			t.Fatalf("unexpected error: %s. Must contain %q", errS, "cannot parse `duration`")
+		}
+	}
+
+	f(DurationGo, "")
+	f(DurationGo, "1x")
+	f(DurationGo, "5 SECOND")
+	f(DurationGo, "9999999999h")
+
+	f(DurationSeconds, "")
+	f(DurationSeconds, "1s")
+	f(DurationSeconds, "1.")
+	f(DurationSeconds, ".5")
+	f(DurationSeconds, "1.5.5")
+	f(DurationSeconds, "9223372037")
+	f(DurationSeconds, "9223372036.854775808")
+	f(DurationSeconds, "-9223372036.854775809")
+
+	f(DurationInterval, "")
+	f(DurationInterval, "5")
+	f(DurationInterval, "INTERVAL 5")
+	f(DurationInterval, "5 SECONDSS")
+	f(DurationInterval, "5  SECOND")
+	f(DurationInterval, "1 MONTH")
+	f(DurationInterval, "1 YEAR")
+	f(DurationInterval, "x SECOND")
+	f(DurationInterval, "INTERVAL5 SECOND")
+	f(DurationInterval, "100000 WEEK")
+
+	f(DurationFormat(100), "1")
+}
+
+func TestReaderDurationOutOfRange(t *testing.T) {
+	f := func(format DurationFormat, s string) {
+		t.Helper()
+
+		r := New(bytes.NewBufferString(s + "\n"))
+		r.Next()
+		r.Duration(format)
+		if err := r.Error(); !errors.Is(err, strconv.ErrRange) {
+			t.Fatalf("expecting strconv.ErrRange when parsing %q in format %d; got %v", s, format, err)
+		}
+	}
+
+	f(DurationSeconds, "9223372037")
+	f(DurationSeconds, "-9223372036.854775809")
+	f(DurationSeconds, "99999999999999999999")
+	f(DurationInterval, "100000 WEEK")
+	f(DurationInterval, "-3000000 HOURS")
+}
+
+func TestReaderDurationE(t *testing.T) {
+	r := New(bytes.NewBufferString("1.5\n"))
+	r.Next()
+	if _, err := r.DurationE(DurationInterval); err == nil {
+		t.Fatalf("expecting non-nil error")
+	}
+	d, err := r.DurationE(DurationSeconds)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if d != 1500*time.Millisecond {
+		t.Fatalf("unexpected duration: %s. Expecting %s", d, 1500*time.Millisecond)
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestReaderDurationNoAllocs(t *testing.T) {
+	data := []byte("1h2m3s\t1.5\tINTERVAL 5 seconds\n")
+	br := bytes.NewReader(data)
+	r := New(br)
+	n := testing.AllocsPerRun(100, func() {
+		br.Reset(data)
+		r.Reset(br)
+		r.Next()
+		if r.Duration(DurationGo) == 0 {
+			panic("unexpected zero duration")
+		}
+		if r.Duration(DurationSeconds) == 0 {
+			panic("unexpected zero duration")
+		}
+		if r.Duration(DurationInterval) == 0 {
+			panic("unexpected zero duration")
+		}
+	})
+	if n != 0 {
+		t.Fatalf("unexpected number of allocations: %v. Expecting 0", n)
+	}
+}
@@ -0,0 +1,93 @@
+package tsvreader
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+var (
+	// ErrNoMoreColumns is returned when reading a column past the end of row.
+	ErrNoMoreColumns = errors.New("no more columns")
+
+	// ErrUnreadColumns is returned from Next when the previous row
+	// contains unread columns.
+	ErrUnreadColumns = errors.New("unread columns")
+
+	// ErrColsCount is returned from Next when the row contains unexpected
+	// number of columns. See Reader.ColsCount for details.
+	ErrColsCount = errors.New("unexpected number of columns")
+
+	// ErrNoNewline is returned when the last row isn't terminated by newline.
+	ErrNoNewline = errors.New("cannot find newline")
+
+	errMissingNext = errors.New("missing Next call")
+)
+
+// errRange is returned for out of range values of small integer types.
+//
+// It keeps the "out of range" message while matching strconv.ErrRange
+// via errors.Is.
+var errRange error = rangeError{}
+
+type rangeError struct{}
+
+func (rangeError) Error() string {
+	return "out of range"
+}
+
+func (rangeError) Is(err error) bool {
+	return err == strconv.ErrRange
+}
+
+// ParseError is the error returned by Reader when the row or column
+// cannot be read.
+//
+// Use errors.As for obtaining ParseError from Reader.Error.
+type ParseError struct {
+	// Row is the row number starting from 1.
+	Row int
+
+	// Col is the column number starting from 1.
+	//
+	// Col is 0 for errors related to the whole row.
+	Col int
+
+	// Type is the expected column type such as `int` or `date`.
+	//
+	// Type is empty if the error isn't related to a particular type.
+	Type string
+
+	// Value is the raw column value.
+	//
+	// Value contains unread columns for ErrUnreadColumns.
+	Value []byte
+
+	// Err is the underlying error.
+	Err error
+
+	msg    string
+	rowBuf []byte
+}
+
+// Error implements error interface.
+func (e *ParseError) Error() string {
+	switch {
+	case e.Err == ErrUnreadColumns:
+		return fmt.Sprintf("row #%d %q contains unread columns: %q", e.Row, e.rowBuf, e.Value)
+	case e.Err == ErrNoNewline:
+		return fmt.Sprintf("cannot find newline at the end of row #%d; row: %q", e.Row, e.rowBuf)
+	case e.Col == 0:
+		return fmt.Sprintf("row #%d %q: %s", e.Row, e.rowBuf, e.Err)
+	}
+	msg := e.msg
+	if e.Type != "" {
+		msg = fmt.Sprintf("%s `%s`", msg, e.Type)
+	}
+	return fmt.Sprintf("%s at row #%d, col #%d %q: %s", msg, e.Row, e.Col, e.rowBuf, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
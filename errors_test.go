@@ -0,0 +1,202 @@
+package tsvreader
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestParseErrorParse(t *testing.T) {
+	b := bytes.NewBufferString("foo\tbar\n")
+	r := New(b)
+	if !r.Next() {
+		t.Fatalf("Next must return true")
+	}
+	r.SkipCol()
+	n := r.Int()
+	if n != 0 {
+		t.Fatalf("unexpected non-zero int: %d", n)
+	}
+	err := r.Error()
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expecting ParseError; got %T: %s", err, err)
+	}
+	if pe.Row != 1 {
+		t.Fatalf("unexpected row: %d. Expecting 1", pe.Row)
+	}
+	if pe.Col != 2 {
+		t.Fatalf("unexpected col: %d. Expecting 2", pe.Col)
+	}
+	if pe.Type != "int" {
+		t.Fatalf("unexpected type: %q. Expecting %q", pe.Type, "int")
+	}
+	if string(pe.Value) != "bar" {
+		t.Fatalf("unexpected value: %q. Expecting %q", pe.Value, "bar")
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Fatalf("expecting strconv.ErrSyntax; got %s", err)
+	}
+	var ne *strconv.NumError
+	if !errors.As(err, &ne) {
+		t.Fatalf("expecting strconv.NumError; got %s", err)
+	}
+	errS := err.Error()
+	expectedS := "cannot parse `int` at row #1, col #2 \"foo\\tbar\": " + ne.Error()
+	if errS != expectedS {
+		t.Fatalf("unexpected error message: %q. Expecting %q", errS, expectedS)
+	}
+}
+
+func TestParseErrorOutOfRange(t *testing.T) {
+	b := bytes.NewBufferString("300\n")
+	r := New(b)
+	r.Next()
+	r.Uint8()
+	err := r.Error()
+	if !errors.Is(err, strconv.ErrRange) {
+		t.Fatalf("expecting strconv.ErrRange; got %v", err)
+	}
+	errS := err.Error()
+	expectedS := "cannot parse `uint8` at row #1, col #1 \"300\": out of range"
+	if errS != expectedS {
+		t.Fatalf("unexpected error message: %q. Expecting %q", errS, expectedS)
+	}
+}
+
+func TestParseErrorOutOfRangeMessage(t *testing.T) {
+	f := func(data string, read func(r *Reader)) {
+		t.Helper()
+
+		r := New(bytes.NewBufferString(data + "\n"))
+		r.Next()
+		read(r)
+		err := r.Error()
+		if err == nil {
+			t.Fatalf("expecting non-nil error for %q", data)
+		}
+		if !errors.Is(err, strconv.ErrRange) {
+			t.Fatalf("expecting strconv.ErrRange for %q; got %v", data, err)
+		}
+		if errS := err.Error(); !strings.HasSuffix(errS, ": out of range") {
+			t.Fatalf("unexpected error message: %q. Must end with %q", errS, ": out of range")
+		}
+	}
+
+	f("40000", func(r *Reader) { r.Int16() })
+	f("70000", func(r *Reader) { r.Uint16() })
+	f("200", func(r *Reader) { r.Int8() })
+	f("-200", func(r *Reader) { r.Int8() })
+	f("300", func(r *Reader) { r.Uint8() })
+}
+
+func TestParseErrorNoMoreColumns(t *testing.T) {
+	b := bytes.NewBufferString("foo\n")
+	r := New(b)
+	r.Next()
+	r.SkipCol()
+	r.Bytes()
+	err := r.Error()
+	if !errors.Is(err, ErrNoMoreColumns) {
+		t.Fatalf("expecting ErrNoMoreColumns; got %v", err)
+	}
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expecting ParseError; got %T: %s", err, err)
+	}
+	if pe.Col != 2 {
+		t.Fatalf("unexpected col: %d. Expecting 2", pe.Col)
+	}
+	if pe.Value != nil {
+		t.Fatalf("unexpected non-nil value: %q", pe.Value)
+	}
+	expectedS := "cannot read `bytes` at row #1, col #2 \"foo\": no more columns"
+	if errS := err.Error(); errS != expectedS {
+		t.Fatalf("unexpected error message: %q. Expecting %q", errS, expectedS)
+	}
+}
+
+func TestParseErrorUnreadColumns(t *testing.T) {
+	b := bytes.NewBufferString("foo\tbar\tbaz\n")
+	r := New(b)
+	r.Next()
+	r.SkipCol()
+	if r.Next() {
+		t.Fatalf("Next must return false")
+	}
+	err := r.Error()
+	if !errors.Is(err, ErrUnreadColumns) {
+		t.Fatalf("expecting ErrUnreadColumns; got %v", err)
+	}
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expecting ParseError; got %T: %s", err, err)
+	}
+	if string(pe.Value) != "bar\tbaz" {
+		t.Fatalf("unexpected value: %q. Expecting %q", pe.Value, "bar\tbaz")
+	}
+	expectedS := "row #1 \"foo\\tbar\\tbaz\" contains unread columns: \"bar\\tbaz\""
+	if errS := err.Error(); errS != expectedS {
+		t.Fatalf("unexpected error message: %q. Expecting %q", errS, expectedS)
+	}
+}
+
+func TestParseErrorNoNewline(t *testing.T) {
+	b := bytes.NewBufferString("foo\nbar")
+	r := New(b)
+	r.Next()
+	r.SkipCol()
+	if r.Next() {
+		t.Fatalf("Next must return false")
+	}
+	err := r.Error()
+	if !errors.Is(err, ErrNoNewline) {
+		t.Fatalf("expecting ErrNoNewline; got %v", err)
+	}
+	expectedS := "cannot find newline at the end of row #2; row: \"bar\""
+	if errS := err.Error(); errS != expectedS {
+		t.Fatalf("unexpected error message: %q. Expecting %q", errS, expectedS)
+	}
+}
+
+func TestParseErrorDate(t *testing.T) {
+	b := bytes.NewBufferString("2017-1x-10\n")
+	r := New(b)
+	r.Next()
+	r.Date()
+	err := r.Error()
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expecting ParseError; got %T: %s", err, err)
+	}
+	if pe.Type != "date" {
+		t.Fatalf("unexpected type: %q. Expecting %q", pe.Type, "date")
+	}
+	if !errors.Is(err, strconv.ErrSyntax) {
+		t.Fatalf("expecting strconv.ErrSyntax; got %s", err)
+	}
+}
+
+func TestParseErrorRawRow(t *testing.T) {
+	b := bytes.NewBufferString("a\\tb\\\\\tfoo\n")
+	r := New(b)
+	r.Next()
+	bb := r.Bytes()
+	if string(bb) != "a\tb\\" {
+		t.Fatalf("unexpected bytes: %q. Expecting %q", bb, "a\tb\\")
+	}
+	if rawRow := r.RawRow(); string(rawRow) != "a\\tb\\\\\tfoo" {
+		t.Fatalf("unexpected raw row: %q. Expecting %q", rawRow, "a\\tb\\\\\tfoo")
+	}
+	r.Int()
+	err := r.Error()
+	if err == nil {
+		t.Fatalf("expecting non-nil error")
+	}
+	expectedS := "cannot parse `int` at row #1, col #2 \"a\\\\tb\\\\\\\\\\tfoo\": "
+	if errS := err.Error(); !strings.HasPrefix(errS, expectedS) {
+		t.Fatalf("unexpected error message: %q. Must start with %q", errS, expectedS)
+	}
+}
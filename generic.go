@@ -0,0 +1,73 @@
+package tsvreader
+
+import (
+	"time"
+)
+
+// ColType is the set of column types supported by Col and ColNull.
+type ColType interface {
+	int | uint | int8 | uint8 | int16 | uint16 | int32 | uint32 | int64 | uint64 |
+		float32 | float64 | string | []byte | time.Time | Date
+}
+
+// Col returns the next column value of type T from the current row.
+//
+// Col calls the corresponding Reader method such as Reader.Int or
+// Reader.Bytes. time.Time is read with Reader.Date if the column contains
+// YYYY-MM-DD. Otherwise it is read with Reader.DateTime.
+//
+// []byte value is valid until the next call to Reader.
+func Col[T ColType](tr *Reader) T {
+	var v T
+	switch p := any(&v).(type) {
+	case *int:
+		*p = tr.Int()
+	case *uint:
+		*p = tr.Uint()
+	case *int8:
+		*p = tr.Int8()
+	case *uint8:
+		*p = tr.Uint8()
+	case *int16:
+		*p = tr.Int16()
+	case *uint16:
+		*p = tr.Uint16()
+	case *int32:
+		*p = tr.Int32()
+	case *uint32:
+		*p = tr.Uint32()
+	case *int64:
+		*p = tr.Int64()
+	case *uint64:
+		*p = tr.Uint64()
+	case *float32:
+		*p = tr.Float32()
+	case *float64:
+		*p = tr.Float64()
+	case *string:
+		*p = tr.String()
+	case *[]byte:
+		*p = tr.Bytes()
+	case *time.Time:
+		if len(tr.PeekBytes()) == len("YYYY-MM-DD") {
+			*p = tr.Date()
+		} else {
+			*p = tr.DateTime()
+		}
+	case *Date:
+		*p = tr.CivilDate()
+	}
+	return v
+}
+
+// ColNull returns the next nullable column value of type T from the current row.
+//
+// false is returned if the column contains NULL. See Reader.SkipNull for details.
+// Otherwise the value is read with Col.
+func ColNull[T ColType](tr *Reader) (T, bool) {
+	if tr.SkipNull() {
+		var zero T
+		return zero, false
+	}
+	return Col[T](tr), true
+}
@@ -0,0 +1,137 @@
+package tsvreader
+
+import (
+	"bytes"
+	"math"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCol(t *testing.T) {
+	b := bytes.NewBufferString("-1\t2\t-3\t4\t-5\t6\t-7\t8\t-9\t10\t1.5\t-2.5\tfoo\\tbar\tbaz\t2017-10-13\t2017-10-13 12:34:56\n")
+	r := New(b)
+	if !r.Next() {
+		t.Fatalf("Next must return true")
+	}
+	if v := Col[int](r); v != -1 {
+		t.Fatalf("unexpected int: %d", v)
+	}
+	if v := Col[uint](r); v != 2 {
+		t.Fatalf("unexpected uint: %d", v)
+	}
+	if v := Col[int8](r); v != -3 {
+		t.Fatalf("unexpected int8: %d", v)
+	}
+	if v := Col[uint8](r); v != 4 {
+		t.Fatalf("unexpected uint8: %d", v)
+	}
+	if v := Col[int16](r); v != -5 {
+		t.Fatalf("unexpected int16: %d", v)
+	}
+	if v := Col[uint16](r); v != 6 {
+		t.Fatalf("unexpected uint16: %d", v)
+	}
+	if v := Col[int32](r); v != -7 {
+		t.Fatalf("unexpected int32: %d", v)
+	}
+	if v := Col[uint32](r); v != 8 {
+		t.Fatalf("unexpected uint32: %d", v)
+	}
+	if v := Col[int64](r); v != -9 {
+		t.Fatalf("unexpected int64: %d", v)
+	}
+	if v := Col[uint64](r); v != 10 {
+		t.Fatalf("unexpected uint64: %d", v)
+	}
+	if v := Col[float32](r); v != 1.5 {
+		t.Fatalf("unexpected float32: %v", v)
+	}
+	if v := Col[float64](r); v != -2.5 {
+		t.Fatalf("unexpected float64: %v", v)
+	}
+	if v := Col[string](r); v != "foo\tbar" {
+		t.Fatalf("unexpected string: %q", v)
+	}
+	if v := Col[[]byte](r); string(v) != "baz" {
+		t.Fatalf("unexpected bytes: %q", v)
+	}
+	if v := Col[time.Time](r); !v.Equal(time.Date(2017, 10, 13, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected date: %s", v)
+	}
+	if v := Col[time.Time](r); !v.Equal(time.Date(2017, 10, 13, 12, 34, 56, 0, time.UTC)) {
+		t.Fatalf("unexpected datetime: %s", v)
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if r.HasCols() {
+		t.Fatalf("HasCols must return false")
+	}
+
+	// Errors must be reported in the same way as for Reader methods.
+	r.Reset(bytes.NewBufferString("foo\n"))
+	r.Next()
+	if v := Col[float64](r); v != 0 {
+		t.Fatalf("unexpected non-zero float64: %v", v)
+	}
+	if err := r.Error(); err == nil || !strings.Contains(err.Error(), "cannot parse `float64` at row #1, col #1") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestColNull(t *testing.T) {
+	b := bytes.NewBufferString("\\N\t42\t\t\\N\tfoo\n")
+	r := New(b)
+	r.EmptyAsDefault = true
+	if !r.Next() {
+		t.Fatalf("Next must return true")
+	}
+	if v, ok := ColNull[int](r); ok || v != 0 {
+		t.Fatalf("expecting NULL; got %d", v)
+	}
+	if v, ok := ColNull[int](r); !ok || v != 42 {
+		t.Fatalf("unexpected value: %d, %v. Expecting 42, true", v, ok)
+	}
+	if v, ok := ColNull[float64](r); ok || v != 0 {
+		t.Fatalf("expecting NULL for empty column; got %v", v)
+	}
+	if v, ok := ColNull[string](r); ok || v != "" {
+		t.Fatalf("expecting NULL; got %q", v)
+	}
+	if v, ok := ColNull[string](r); !ok || v != "foo" {
+		t.Fatalf("unexpected value: %q, %v. Expecting %q, true", v, ok, "foo")
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if r.HasCols() {
+		t.Fatalf("HasCols must return false")
+	}
+}
+
+func TestColNoAllocs(t *testing.T) {
+	data := []byte("123\t-1.5\tfoo\t2017-10-13 12:34:56\n")
+	br := bytes.NewReader(data)
+	r := New(br)
+	n := testing.AllocsPerRun(100, func() {
+		br.Reset(data)
+		r.Reset(br)
+		r.Next()
+		if Col[int](r) != 123 {
+			panic("unexpected int")
+		}
+		if math.Abs(Col[float64](r)+1.5) > 1e-9 {
+			panic("unexpected float64")
+		}
+		if string(Col[[]byte](r)) != "foo" {
+			panic("unexpected bytes")
+		}
+		if Col[time.Time](r).IsZero() {
+			panic("unexpected datetime")
+		}
+	})
+	if n != 0 {
+		t.Fatalf("unexpected number of allocations: %v. Expecting 0", n)
+	}
+}
@@ -0,0 +1,3 @@
+module github.com/valyala/tsvreader
+
+go 1.22
@@ -0,0 +1,53 @@
+package tsvreader
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// JSON unmarshals the next JSON column value from the current row into v.
+//
+// The column is unescaped and passed to json.Unmarshal without copying
+// into an intermediate string.
+func (tr *Reader) JSON(v any) {
+	if tr.err != nil {
+		return
+	}
+	b, err := tr.nextCol()
+	if err != nil {
+		tr.setColError("cannot read", "json", nil, err)
+		return
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
+		return
+	}
+	if err := json.Unmarshal(tr.unescapeCol(b), v); err != nil {
+		tr.setColError("cannot parse", "json", b, err)
+	}
+}
+
+// RawJSON returns the next JSON column value from the current row.
+//
+// The column is unescaped and validated, so it may be decoded later
+// with json.Unmarshal.
+//
+// The returned value is valid until the next call to Reader.
+func (tr *Reader) RawJSON() json.RawMessage {
+	if tr.err != nil {
+		return nil
+	}
+	b, err := tr.nextCol()
+	if err != nil {
+		tr.setColError("cannot read", "json", nil, err)
+		return nil
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
+		return nil
+	}
+	s := tr.unescapeCol(b)
+	if !json.Valid(s) {
+		tr.setColError("cannot parse", "json", b, fmt.Errorf("invalid JSON"))
+		return nil
+	}
+	return s
+}
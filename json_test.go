@@ -0,0 +1,109 @@
+package tsvreader
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestReaderJSON(t *testing.T) {
+	b := bytes.NewBufferString("{\"a\":1,\"b\":\"x\\\\ty\"}\t[1,2]\n")
+	r := New(b)
+	r.Next()
+
+	var v struct {
+		A int
+		B string
+	}
+	r.JSON(&v)
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v.A != 1 || v.B != "x\ty" {
+		t.Fatalf("unexpected value: %+v. Expecting {A:1 B:\"x\\ty\"}", v)
+	}
+	var a []int
+	r.JSON(&a)
+	if len(a) != 2 || a[0] != 1 || a[1] != 2 {
+		t.Fatalf("unexpected value: %v. Expecting [1 2]", a)
+	}
+	if rawRow := r.RawRow(); string(rawRow) != "{\"a\":1,\"b\":\"x\\\\ty\"}\t[1,2]" {
+		t.Fatalf("JSON mustn't modify the row; got %q", rawRow)
+	}
+}
+
+func TestReaderJSONFailure(t *testing.T) {
+	b := bytes.NewBufferString("{\"a\":\"foo\"}\n")
+	r := New(b)
+	r.Next()
+
+	var v struct {
+		A int
+	}
+	r.JSON(&v)
+	err := r.Error()
+	var ue *json.UnmarshalTypeError
+	if !errors.As(err, &ue) {
+		t.Fatalf("expecting json.UnmarshalTypeError; got %v", err)
+	}
+	if errS := err.Error(); !strings.Contains(errS, "cannot parse `json` at row #1, col #1") {
+		t.Fatalf("unexpected error: %s", errS)
+	}
+	if v.A != 0 {
+		t.Fatalf("unexpected value: %d. Expecting 0", v.A)
+	}
+}
+
+func TestReaderRawJSON(t *testing.T) {
+	b := bytes.NewBufferString("{\"a\":\"x\\\\ty\"}\tnull\t\t{\"a\":\n")
+	r := New(b)
+	r.EmptyAsDefault = true
+	r.Next()
+
+	m := r.RawJSON()
+	if string(m) != "{\"a\":\"x\\ty\"}" {
+		t.Fatalf("unexpected value: %q. Expecting %q", m, "{\"a\":\"x\\ty\"}")
+	}
+	var v map[string]string
+	if err := json.Unmarshal(m, &v); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if v["a"] != "x\ty" {
+		t.Fatalf("unexpected value: %q. Expecting %q", v["a"], "x\ty")
+	}
+	if m := r.RawJSON(); string(m) != "null" {
+		t.Fatalf("unexpected value: %q. Expecting %q", m, "null")
+	}
+	if m := r.RawJSON(); m != nil {
+		t.Fatalf("unexpected non-nil value: %q", m)
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if m := r.RawJSON(); m != nil {
+		t.Fatalf("unexpected non-nil value: %q", m)
+	}
+	if errS := r.Error().Error(); !strings.Contains(errS, "cannot parse `json` at row #1, col #4") {
+		t.Fatalf("unexpected error: %s", errS)
+	}
+}
+
+func TestReaderRawJSONNoAllocs(t *testing.T) {
+	data := []byte("{\"a\":[1,2,3],\"b\":\"x\\\\ty\"}\n")
+	br := bytes.NewReader(data)
+	r := New(br)
+	n := testing.AllocsPerRun(100, func() {
+		br.Reset(data)
+		r.Reset(br)
+		r.Next()
+		if len(r.RawJSON()) == 0 {
+			panic("unexpected empty JSON")
+		}
+	})
+	if n != 0 {
+		t.Fatalf("unexpected number of allocations: %v. Expecting 0", n)
+	}
+}
@@ -0,0 +1,248 @@
+package tsvreader
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// LayoutCompactDate is the layout for dates in the format YYYYMMDD.
+const LayoutCompactDate = "20060102"
+
+// LayoutISO8601 is the layout for ISO 8601 datetime without time zone.
+const LayoutISO8601 = "2006-01-02T15:04:05"
+
+// Time returns the next time column value in the given layout from the current row.
+//
+// The layout is interpreted as in time.Parse. The following layouts are
+// usually parsed without memory allocations by hand-written parsers,
+// which return the same results as time.Parse:
+//
+//   - time.DateOnly
+//   - time.DateTime
+//   - LayoutCompactDate
+//   - time.RFC3339
+//   - time.RFC3339Nano
+//
+// LayoutISO8601 is usually parsed without memory allocations too. Unlike time.Parse,
+// it accepts optional zone offsets in the formats Z, ±hh:mm, ±hhmm and ±hh
+// after the optional fractional seconds. The time is in UTC if the zone
+// offset is missing.
+//
+// Dates and times are always validated, i.e. StrictDates doesn't affect Time.
+// The returned time is always in UTC. 0000-00-00 dates result in zero time.
+func (tr *Reader) Time(layout string) time.Time {
+	if tr.err != nil {
+		return zeroTime
+	}
+	b, err := tr.nextCol()
+	if err != nil {
+		tr.setColError("cannot read", "time", nil, err)
+		return zeroTime
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
+		return zeroTime
+	}
+	s := b2s(b)
+
+	t, err := parseTimeLayout(layout, s)
+	if err != nil {
+		tr.setColError("cannot parse", "time", b, err)
+		return zeroTime
+	}
+	return t
+}
+
+func parseTimeLayout(layout, s string) (time.Time, error) {
+	if t, err := parseTimeLayoutFast(layout, s); err == nil {
+		return t, nil
+	}
+
+	// Slow path - fall back to time.Parse. This covers custom layouts
+	// and rare forms, which aren't supported by fast parsers, such as
+	// single-digit hours. time.Parse returns the proper error for invalid values.
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return zeroTime, err
+	}
+	return t.UTC(), nil
+}
+
+// parseTimeLayoutFast parses s in the given layout without memory allocations.
+//
+// It accepts only values accepted by time.Parse, except for 0000-00-00 dates
+// and zone offsets for LayoutISO8601. A non-nil error is returned if s
+// must be parsed with time.Parse.
+func parseTimeLayoutFast(layout, s string) (time.Time, error) {
+	switch layout {
+	case time.DateOnly:
+		y, m, d, err := parseDate(s, true)
+		if err != nil {
+			return zeroTime, err
+		}
+		return dateTime(y, m, d, 0, 0, 0, 0), nil
+	case time.DateTime:
+		return parseClockDateTime(s, ' ', zoneNone)
+	case LayoutCompactDate:
+		return parseCompactDate(s)
+	case LayoutISO8601:
+		return parseClockDateTime(s, 'T', zoneISO8601)
+	case time.RFC3339, time.RFC3339Nano:
+		return parseClockDateTime(s, 'T', zoneRFC3339)
+	default:
+		return zeroTime, errNoFastParser
+	}
+}
+
+var errNoFastParser = errors.New("no fast parser for the layout")
+
+func parseCompactDate(s string) (time.Time, error) {
+	if len(s) != len("YYYYMMDD") || !isDigits(s) {
+		return zeroTime, fmt.Errorf("invalid date format. Must be YYYYMMDD")
+	}
+	y := atoiDigits(s[:4])
+	m := atoiDigits(s[4:6])
+	d := atoiDigits(s[6:])
+	if err := checkDate(y, m, d); err != nil {
+		return zeroTime, err
+	}
+	return dateTime(y, m, d, 0, 0, 0, 0), nil
+}
+
+// zoneFormat is the set of zone offset formats accepted by parseClockDateTime.
+type zoneFormat int
+
+const (
+	// zoneNone doesn't allow zone offsets.
+	zoneNone zoneFormat = iota
+
+	// zoneISO8601 allows optional Z, ±hh:mm, ±hhmm and ±hh.
+	zoneISO8601
+
+	// zoneRFC3339 requires Z or ±hh:mm.
+	zoneRFC3339
+)
+
+// parseClockDateTime parses YYYY-MM-DD<sep>hh:mm:ss[.fraction][zone].
+//
+// Fractional seconds may be separated by '.' or ',' as in time.Parse.
+func parseClockDateTime(s string, sep byte, zf zoneFormat) (time.Time, error) {
+	if len(s) < len("YYYY-MM-DDThh:mm:ss") {
+		return zeroTime, fmt.Errorf("too short datetime")
+	}
+	y, m, d, err := parseDate(s[:len("YYYY-MM-DD")], true)
+	if err != nil {
+		return zeroTime, err
+	}
+	s = s[len("YYYY-MM-DD"):]
+	if s[0] != sep {
+		return zeroTime, fmt.Errorf("missing %q separator between date and time", sep)
+	}
+	h, min, sec, err := parseClock(s[1:len("Thh:mm:ss")], true)
+	if err != nil {
+		return zeroTime, err
+	}
+	s = s[len("Thh:mm:ss"):]
+
+	nsec := 0
+	if len(s) > 0 && (s[0] == '.' || s[0] == ',') {
+		n := 1
+		for n < len(s) && s[n] >= '0' && s[n] <= '9' {
+			n++
+		}
+		if n == 1 {
+			return zeroTime, fmt.Errorf("missing fractional seconds after %q", s[0])
+		}
+		nsec = parseNanos(s[1:n])
+		s = s[n:]
+	}
+
+	offset := 0
+	switch {
+	case len(s) == 0:
+		if zf == zoneRFC3339 {
+			return zeroTime, fmt.Errorf("missing time zone")
+		}
+	case zf == zoneNone:
+		return zeroTime, fmt.Errorf("unexpected trailing data %q", s)
+	case s == "Z":
+	case s[0] == '+' || s[0] == '-':
+		offset, err = parseZoneOffset(s, zf)
+		if err != nil {
+			return zeroTime, err
+		}
+	default:
+		return zeroTime, fmt.Errorf("unexpected trailing data %q", s)
+	}
+	t := dateTime(y, m, d, h, min, sec, nsec)
+	if offset != 0 && !t.IsZero() {
+		t = t.Add(-time.Duration(offset) * time.Second)
+	}
+	return t, nil
+}
+
+// parseZoneOffset parses ±hh:mm and returns the offset in seconds.
+//
+// ±hhmm and ±hh are accepted too for zoneISO8601.
+// The offset limits match time.Parse, i.e. hh up to 24 and mm up to 60.
+func parseZoneOffset(s string, zf zoneFormat) (int, error) {
+	sign := 1
+	if s[0] == '-' {
+		sign = -1
+	}
+	zs := s[1:]
+	var hS, mS string
+	switch {
+	case len(zs) == len("hh:mm") && zs[2] == ':':
+		hS, mS = zs[:2], zs[3:]
+	case len(zs) == len("hhmm") && zf == zoneISO8601:
+		hS, mS = zs[:2], zs[2:]
+	case len(zs) == len("hh") && zf == zoneISO8601:
+		hS = zs
+	default:
+		return 0, fmt.Errorf("invalid time zone offset %q", s)
+	}
+	if !isDigits(hS) || !isDigits(mS) {
+		return 0, fmt.Errorf("invalid time zone offset %q", s)
+	}
+	h := atoiDigits(hS)
+	m := atoiDigits(mS)
+	if h > 24 || m > 60 {
+		return 0, fmt.Errorf("time zone offset out of range: %q", s)
+	}
+	return sign * (h*3600 + m*60), nil
+}
+
+// parseNanos converts fractional seconds digits into nanoseconds.
+//
+// Digits after the 9th are ignored.
+func parseNanos(s string) int {
+	if len(s) > 9 {
+		s = s[:9]
+	}
+	n := atoiDigits(s)
+	for i := len(s); i < 9; i++ {
+		n *= 10
+	}
+	return n
+}
+
+// atoiDigits converts s containing only decimal digits to int.
+func atoiDigits(s string) int {
+	n := 0
+	for i := 0; i < len(s); i++ {
+		n = n*10 + int(s[i]-'0')
+	}
+	return n
+}
+
+// dateTime returns UTC time for the given components.
+//
+// Zero time is returned for 0000-00-00, which is used by ClickHouse
+// for zero dates.
+func dateTime(y, m, d, h, min, sec, nsec int) time.Time {
+	if y == 0 && m == 0 && d == 0 {
+		return zeroTime
+	}
+	return time.Date(y, time.Month(m), d, h, min, sec, nsec, time.UTC)
+}
@@ -0,0 +1,182 @@
+package tsvreader
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReaderTimeSuccess(t *testing.T) {
+	f := func(layout, s string, expected time.Time) {
+		t.Helper()
+
+		r := New(bytes.NewBufferString(s + "\n"))
+		r.Next()
+		tm := r.Time(layout)
+		if err := r.Error(); err != nil {
+			t.Fatalf("unexpected error when parsing %q in layout %q: %s", s, layout, err)
+		}
+		if !tm.Equal(expected) {
+			t.Fatalf("unexpected time when parsing %q in layout %q: %s. Expecting %s", s, layout, tm, expected)
+		}
+		if tm.Location() != time.UTC {
+			t.Fatalf("unexpected location when parsing %q in layout %q: %s. Expecting UTC", s, layout, tm.Location())
+		}
+	}
+
+	f(time.DateOnly, "2023-05-01", time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC))
+	f(time.DateOnly, "0000-00-00", time.Time{})
+	f(time.DateTime, "2023-05-01 12:34:56", time.Date(2023, 5, 1, 12, 34, 56, 0, time.UTC))
+	f(LayoutCompactDate, "20230501", time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC))
+	f(LayoutCompactDate, "00000000", time.Time{})
+	f(LayoutISO8601, "2023-05-01T12:00:00", time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC))
+	f(LayoutISO8601, "2023-05-01T12:00:00.5", time.Date(2023, 5, 1, 12, 0, 0, 5e8, time.UTC))
+	f(LayoutISO8601, "2023-05-01T12:00:00,123+0100", time.Date(2023, 5, 1, 11, 0, 0, 123e6, time.UTC))
+	f(LayoutISO8601, "2023-05-01T12:00:00-03", time.Date(2023, 5, 1, 15, 0, 0, 0, time.UTC))
+	f(time.RFC3339, "2023-05-01T12:00:00Z", time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC))
+	f(time.RFC3339, "2023-05-01T12:00:00+02:00", time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC))
+	f(time.RFC3339, "2023-05-01T01:00:00+02:00", time.Date(2023, 4, 30, 23, 0, 0, 0, time.UTC))
+	f(time.RFC3339Nano, "2023-05-01T12:00:00.123456789Z", time.Date(2023, 5, 1, 12, 0, 0, 123456789, time.UTC))
+	f(time.RFC3339Nano, "2023-05-01T12:00:00.1234567891-00:30", time.Date(2023, 5, 1, 12, 30, 0, 123456789, time.UTC))
+
+	// Layouts without fast parsers.
+	f(time.RFC1123, "Mon, 01 May 2023 12:00:00 UTC", time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC))
+	f("02.01.2006 15:04 -0700", "01.05.2023 12:00 +0200", time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC))
+}
+
+func TestReaderTimeFailure(t *testing.T) {
+	f := func(layout, s string) {
+		t.Helper()
+
+		r := New(bytes.NewBufferString(s + "\n"))
+		r.Next()
+		tm := r.Time(layout)
+		if !tm.IsZero() {
+			t.Fatalf("expecting zero time when parsing %q in layout %q; got %s", s, layout, tm)
+		}
+		err := r.Error()
+		if err == nil {
+			t.Fatalf("expecting non-nil error when parsing %q in layout %q", s, layout)
+		}
+		if errS := err.Error(); !strings.Contains(errS, "cannot parse `time`") {
+			t.Fatalf("unexpected error: %s. Must contain %q", errS, "cannot parse `time`")
+		}
+	}
+
+	f(time.DateOnly, "2023-05-1")
+	f(time.DateOnly, "2023-02-30")
+	f(time.DateTime, "2023-05-01T12:34:56")
+	f(LayoutCompactDate, "2023051")
+	f(LayoutCompactDate, "2023-5-1")
+	f(LayoutCompactDate, "20231301")
+	f(LayoutISO8601, "2023-05-01 12:00:00")
+	f(LayoutISO8601, "2023-05-01T12:00")
+	f(LayoutISO8601, "2023-05-01T12:00:00.")
+	f(LayoutISO8601, "2023-05-01T12:00:00+1")
+	f(LayoutISO8601, "2023-05-01T12:00:00+01:0")
+	f(LayoutISO8601, "2023-05-01T12:00:00+25:00")
+	f(LayoutISO8601, "2023-05-01T12:00:00 UTC")
+	f(LayoutISO8601, "2023-05-01T25:00:00")
+	f(time.RFC3339, "2023-05-01T12:00:00")
+	f(time.RFC3339Nano, "2023-05-01T12:00:00.123")
+	f(time.RFC1123, "foobar")
+}
+
+func TestReaderTimeMatchesTimeParse(t *testing.T) {
+	inputs := []string{
+		"2023-05-01", "2023-02-31", "2023-02-29", "2024-02-29", "2023-05-00", "2023-13-01",
+		"+023-01-01", "2023-1-01", "2023-01-1", "0000-01-01", "2023-05-01x",
+		"2023-05-01 12:34:56", "2023-05-01 24:00:00", "2023-05-01 23:59:60", "2023-05-01 12:60:00",
+		"2023-05-01 12:34:56.5", "2023-05-01 12:34:56,5", "2023-05-01 12:34:56.", "2023-05-01 +2:34:56",
+		"2023-02-31 12:00:00", "2023-05-01 12:34:5", "2023-05-01 12:34:56Z",
+		"20230501", "20230231", "20231301", "+0230501", "2023051", "202305011",
+		"2023-05-01T12:00:00Z", "2023-05-01T12:00:00z", "2023-02-31T00:00:00Z", "2023-05-01T12:00:00+02:00",
+		"2023-05-01T12:00:00+0200", "2023-05-01T12:00:00+02", "2023-05-01T12:00:00+24:00", "2023-05-01T12:00:00+23:60",
+		"2023-05-01T12:00:00+25:00", "2023-05-01T12:00:00+23:61", "2023-05-01T12:00:00-00:00", "2023-05-01T12:00:00+0a:00",
+		"2023-05-01T12:00:00.123Z", "2023-05-01T12:00:00,123Z", "2023-05-01T12:00:00.Z", "2023-05-01T12:00:00.1234567891Z",
+		"2023-05-01T12:00:00", "2023-05-01t12:00:00Z", "2023-05-01T24:00:00Z", "2023-05-01T12:00:60Z",
+		"2023-05-01T+2:00:00Z", "0000-01-01T00:00:00Z", "2023-05-01T12:00:00+02:00:00", "2023-05-01T12:00:00 +02:00",
+	}
+
+	// Mutate every byte of the valid values in order to cover
+	// more corner cases.
+	for _, s := range []string{"2024-02-29 23:59:59.5", "20240229", "2024-02-29T23:59:59.123-12:30"} {
+		for i := 0; i < len(s); i++ {
+			for _, c := range []byte("0139+- :.,TZ") {
+				b := []byte(s)
+				b[i] = c
+				inputs = append(inputs, string(b))
+			}
+		}
+	}
+
+	layouts := []string{time.DateOnly, time.DateTime, LayoutCompactDate, time.RFC3339, time.RFC3339Nano}
+	for _, layout := range layouts {
+		for _, s := range inputs {
+			testReaderTimeMatchesTimeParse(t, layout, s)
+		}
+	}
+
+	// LayoutISO8601 must match time.Parse for values without zone offset.
+	for _, s := range inputs {
+		if len(s) > len("YYYY-MM-DDThh:mm:ss") && strings.ContainsAny(s[len("YYYY-MM-DDThh:mm:ss"):], "Z+-") {
+			continue
+		}
+		testReaderTimeMatchesTimeParse(t, LayoutISO8601, s)
+	}
+}
+
+func testReaderTimeMatchesTimeParse(t *testing.T, layout, s string) {
+	t.Helper()
+
+	if strings.HasPrefix(s, "0000-00-00") || strings.HasPrefix(s, "00000000") {
+		// Zero dates are handled in ClickHouse-compatible way.
+		return
+	}
+	expected, expectedErr := time.Parse(layout, s)
+
+	r := New(bytes.NewBufferString(s + "\n"))
+	r.Next()
+	tm := r.Time(layout)
+	err := r.Error()
+	if (err == nil) != (expectedErr == nil) {
+		t.Fatalf("unexpected error when parsing %q in layout %q: %v. Expecting %v", s, layout, err, expectedErr)
+	}
+	if !tm.Equal(expected) {
+		t.Fatalf("unexpected time when parsing %q in layout %q: %s. Expecting %s", s, layout, tm, expected)
+	}
+}
+
+func TestReaderTimeIgnoresStrictDates(t *testing.T) {
+	r := New(bytes.NewBufferString("2023-02-31\t+023-01-01\n"))
+	r.StrictDates = false
+	r.Next()
+	if _, err := r.TimeE(time.DateOnly); err == nil {
+		t.Fatalf("expecting non-nil error for invalid day")
+	}
+	r.SkipCol()
+	if _, err := r.TimeE(time.DateOnly); err == nil {
+		t.Fatalf("expecting non-nil error for invalid year")
+	}
+}
+
+func TestReaderTimeNoAllocs(t *testing.T) {
+	data := []byte("2023-05-01T12:00:00.123+02:00\t20230501\n")
+	br := bytes.NewReader(data)
+	r := New(br)
+	n := testing.AllocsPerRun(100, func() {
+		br.Reset(data)
+		r.Reset(br)
+		r.Next()
+		if r.Time(time.RFC3339Nano).IsZero() {
+			panic("unexpected zero time")
+		}
+		if r.Time(LayoutCompactDate).IsZero() {
+			panic("unexpected zero date")
+		}
+	})
+	if n != 0 {
+		t.Fatalf("unexpected number of allocations: %v. Expecting 0", n)
+	}
+}
@@ -0,0 +1,158 @@
+package tsvreader
+
+import (
+	"database/sql"
+	"encoding"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Scan reads the next len(dst) columns from the current row into dst.
+//
+// Every dst item must be a pointer to a type supported by Col,
+// to a type registered with RegisterDecoder, encoding.TextUnmarshaler
+// or sql.Scanner. Decoders from Reader.Decoders take precedence over
+// the built-in parsing and over registered decoders. Registered decoders
+// take precedence over encoding.TextUnmarshaler and sql.Scanner.
+// sql.Scanner receives nil for NULL columns (see Reader.SkipNull) and string
+// for other columns. []byte values are copied, so they remain valid after
+// the next call to Reader.
+//
+// The returned error is also available via Error.
+func (tr *Reader) Scan(dst ...any) error {
+	for _, v := range dst {
+		if tr.err != nil {
+			return tr.err
+		}
+		if len(tr.Decoders) > 0 {
+			if f := tr.readerDecoder(v); f != nil {
+				tr.decode(v, f)
+				continue
+			}
+		}
+		switch p := v.(type) {
+		case *int:
+			*p = tr.Int()
+		case *uint:
+			*p = tr.Uint()
+		case *int8:
+			*p = tr.Int8()
+		case *uint8:
+			*p = tr.Uint8()
+		case *int16:
+			*p = tr.Int16()
+		case *uint16:
+			*p = tr.Uint16()
+		case *int32:
+			*p = tr.Int32()
+		case *uint32:
+			*p = tr.Uint32()
+		case *int64:
+			*p = tr.Int64()
+		case *uint64:
+			*p = tr.Uint64()
+		case *float32:
+			*p = tr.Float32()
+		case *float64:
+			*p = tr.Float64()
+		case *string:
+			*p = tr.String()
+		case *[]byte:
+			*p = append((*p)[:0], tr.Bytes()...)
+		case *time.Time:
+			*p = Col[time.Time](tr)
+		case *Date:
+			*p = tr.CivilDate()
+		default:
+			tr.scanValue(v)
+		}
+	}
+	return tr.err
+}
+
+// readerDecoder returns the decoder from Decoders for the type v points to.
+func (tr *Reader) readerDecoder(v any) DecodeFunc {
+	pt := reflect.TypeOf(v)
+	if pt == nil || pt.Kind() != reflect.Ptr {
+		return nil
+	}
+	return tr.Decoders[pt.Elem()]
+}
+
+func (tr *Reader) scanValue(v any) {
+	if f := lookupDecoder(reflect.TypeOf(v)); f != nil {
+		tr.decode(v, f)
+		return
+	}
+	switch p := v.(type) {
+	case sql.Scanner:
+		tr.scanScanner(p)
+	case encoding.TextUnmarshaler:
+		tr.Text(p)
+	default:
+		b, err := tr.nextCol()
+		if err == nil {
+			err = fmt.Errorf("unsupported destination type %T", v)
+		}
+		tr.setColError("cannot scan", typeName(v), b, err)
+	}
+}
+
+func (tr *Reader) decode(v any, f DecodeFunc) {
+	if tr.err != nil {
+		return
+	}
+	b, err := tr.nextCol()
+	if err != nil {
+		tr.setColError("cannot read", typeName(v), nil, err)
+		return
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
+		setZero(v)
+		return
+	}
+	if err := f(v, tr.unescapeCol(b)); err != nil {
+		tr.setColError("cannot decode", typeName(v), b, err)
+	}
+}
+
+func (tr *Reader) scanScanner(s sql.Scanner) {
+	if tr.SkipNull() {
+		if err := s.Scan(nil); err != nil {
+			tr.setColError("cannot scan", typeName(s), nil, err)
+		}
+		return
+	}
+	if tr.err != nil {
+		return
+	}
+	b, err := tr.nextCol()
+	if err != nil {
+		tr.setColError("cannot read", typeName(s), nil, err)
+		return
+	}
+	if err := s.Scan(string(tr.unescapeCol(b))); err != nil {
+		tr.setColError("cannot scan", typeName(s), b, err)
+	}
+}
+
+// setZero sets the value v points to to zero value.
+//
+// This makes EmptyAsDefault work for custom types the same way
+// as for built-in types.
+func setZero(v any) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv.Elem().SetZero()
+	}
+}
+
+// typeName returns the name of the type v points to.
+func typeName(v any) string {
+	t := reflect.TypeOf(v)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.String()
+}
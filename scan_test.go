@@ -0,0 +1,94 @@
+package tsvreader
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReaderScan(t *testing.T) {
+	b := bytes.NewBufferString("42\tfoo\\tbar\t2017-10-13 12:34:56\t-1.5\tbaz\t127.0.0.1\t\\N\t123\n")
+	r := New(b)
+	if !r.Next() {
+		t.Fatalf("Next must return true")
+	}
+	var (
+		n   int
+		s   string
+		ts  time.Time
+		f   float32
+		bb  []byte
+		ip  net.IP
+		ns1 sql.NullInt64
+		ns2 sql.NullInt64
+	)
+	if err := r.Scan(&n, &s, &ts, &f, &bb, &ip, &ns1, &ns2); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n != 42 {
+		t.Fatalf("unexpected int: %d. Expecting 42", n)
+	}
+	if s != "foo\tbar" {
+		t.Fatalf("unexpected string: %q. Expecting %q", s, "foo\tbar")
+	}
+	if !ts.Equal(time.Date(2017, 10, 13, 12, 34, 56, 0, time.UTC)) {
+		t.Fatalf("unexpected time: %s", ts)
+	}
+	if f != -1.5 {
+		t.Fatalf("unexpected float32: %v. Expecting -1.5", f)
+	}
+	if string(bb) != "baz" {
+		t.Fatalf("unexpected bytes: %q. Expecting %q", bb, "baz")
+	}
+	if ip.String() != "127.0.0.1" {
+		t.Fatalf("unexpected ip: %s. Expecting 127.0.0.1", ip)
+	}
+	if ns1.Valid {
+		t.Fatalf("expecting NULL; got %v", ns1)
+	}
+	if !ns2.Valid || ns2.Int64 != 123 {
+		t.Fatalf("unexpected value: %v. Expecting 123", ns2)
+	}
+	if r.HasCols() {
+		t.Fatalf("HasCols must return false")
+	}
+	if r.Next() {
+		t.Fatalf("Next must return false")
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestReaderScanError(t *testing.T) {
+	testReaderScanError(t, "foo\n", "cannot parse `int` at row #1, col #1", new(int))
+	testReaderScanError(t, "1\n", "cannot read `bytes` at row #1, col #2", new(int), new(string))
+	testReaderScanError(t, "1\tfoo\n", "cannot parse `net.IP` at row #1, col #2", new(int), new(net.IP))
+	testReaderScanError(t, "1\tfoo\n", "cannot scan `sql.NullInt64` at row #1, col #2", new(int), new(sql.NullInt64))
+	testReaderScanError(t, "1\tfoo\n", "cannot scan `struct {}` at row #1, col #2", new(int), new(struct{}))
+}
+
+func testReaderScanError(t *testing.T, s, expectedErr string, dst ...any) {
+	t.Helper()
+
+	r := New(bytes.NewBufferString(s))
+	r.Next()
+	err := r.Scan(dst...)
+	if err == nil {
+		t.Fatalf("expecting non-nil error")
+	}
+	if !errors.Is(r.Error(), err) {
+		t.Fatalf("Error must return the same error as Scan; got %v; want %v", r.Error(), err)
+	}
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expecting ParseError; got %T", err)
+	}
+	if errS := err.Error(); !strings.Contains(errS, expectedErr) {
+		t.Fatalf("unexpected error: %s. Must contain %q", errS, expectedErr)
+	}
+}
@@ -2,9 +2,11 @@ package tsvreader
 
 import (
 	"bytes"
+	"encoding"
 	"fmt"
 	"io"
 	"math"
+	"reflect"
 	"strconv"
 	"time"
 	"unsafe"
@@ -24,7 +26,111 @@ func New(r io.Reader) *Reader {
 //
 // It is expected that columns are separated by tabs while rows
 // are separated by newlines.
+//
+// Reader settings must be set before the first call to Next.
 type Reader struct {
+	// SkipInvalidRows enables lenient mode.
+	//
+	// In lenient mode a column error marks the current row as invalid.
+	// The next call to Next skips the rest of the invalid row and advances
+	// to the following row. Rows with unread columns aren't skipped,
+	// since their values have been already read by the caller. Next fails
+	// with ErrUnreadColumns for them as in strict mode. Set ColsCount
+	// or FixedColsCount for skipping rows with unexpected number of columns.
+	// Errors for skipped rows are passed to RowErrorHandler if it is set.
+	// Otherwise they are collected and may be obtained via RowErrors.
+	SkipInvalidRows bool
+
+	// RowErrorHandler is called with the error for each skipped row
+	// in lenient mode.
+	RowErrorHandler func(err error)
+
+	// MaxRowErrors is the maximum number of errors collected in lenient mode.
+	//
+	// Errors for skipped rows exceeding MaxRowErrors aren't collected,
+	// but they are still counted by SkippedRows.
+	// 100 errors are collected if MaxRowErrors isn't set.
+	MaxRowErrors int
+
+	// RejectedRowsWriter receives rows skipped in lenient mode.
+	//
+	// Only rows rejected by the reader are written, i.e. rows with column
+	// errors and rows with unexpected number of columns. Rows successfully
+	// read by the caller are never written, so replaying the rejected rows
+	// doesn't result in duplicates.
+	//
+	// Each skipped row is written as is, i.e. without unescaping,
+	// prefixed with the escaped error message and a tab.
+	// So the rejected rows may be fixed and read again with the Reader
+	// after skipping the first column.
+	RejectedRowsWriter io.Writer
+
+	// AllowMissingNewline allows the last row without the trailing newline.
+	//
+	// By default Next returns ErrNoNewline error for such a row.
+	AllowMissingNewline bool
+
+	// IgnoreUnreadCols disables ErrUnreadColumns check in Next.
+	//
+	// This allows reading only the first columns in every row, so the reader
+	// remains compatible with rows containing additional columns.
+	// See also SkipRestCols.
+	IgnoreUnreadCols bool
+
+	// EmptyAsDefault makes typed accessors such as Int, Float64 or Date
+	// to return zero value for empty columns instead of an error.
+	//
+	// This is similar to input_format_tsv_empty_as_default setting
+	// in ClickHouse.
+	EmptyAsDefault bool
+
+	// StrictDates enables strict validation in Date, CivilDate and DateTime.
+	//
+	// By default out of range values such as 2023-02-31 or 25:61:61
+	// are normalized into the following days. Such values result
+	// in errors if StrictDates is set. 0000-00-00 is still allowed.
+	StrictDates bool
+
+	// HasHeader must be set if the first row contains column names.
+	//
+	// The header row is read by the first call to Next.
+	// Column names may be obtained via Header.
+	HasHeader bool
+
+	// Projection contains indexes for the columns to read. Indexes start from 0.
+	//
+	// If Projection is set, then only the given columns are located in every
+	// row and Reader methods read them in the given order.
+	// All the other columns are ignored.
+	Projection []int
+
+	// ProjectionNames contains names for the columns to read.
+	//
+	// ProjectionNames works like Projection, but requires HasHeader.
+	ProjectionNames []string
+
+	// ColsCount is the expected number of columns in every row.
+	//
+	// Next returns ErrColsCount error for rows with different number
+	// of columns before reading any column. Such rows are skipped
+	// in lenient mode. An empty row is treated as a row with a single column.
+	//
+	// Rows may contain arbitrary number of columns by default.
+	ColsCount int
+
+	// FixedColsCount enables ColsCount check when ColsCount isn't set.
+	//
+	// In this case the expected number of columns is obtained from the header
+	// or from the first row.
+	FixedColsCount bool
+
+	// Decoders contains decoders for Scan destinations of the given types.
+	//
+	// Decoders take precedence over the built-in parsing and over decoders
+	// registered with RegisterDecoder. This allows, for instance, decoding
+	// time.Time columns in a custom layout without affecting other Readers.
+	Decoders map[reflect.Type]DecodeFunc
+
 	r    io.Reader
 	rb   []byte
 	rErr error
@@ -37,11 +143,39 @@ type Reader struct {
 	b       []byte
 	scratch []byte
 
-	err          error
+	rawRowBuf    []byte
+	rowUnescaped bool
+	rejectBuf    []byte
+
+	colOffs      []int
+	colOffsValid bool
+	colBuf       []byte
+
+	header    []string
+	proj      []int
+	projPos   int
+	projMax   int
+	colsCount int
+
+	err    error
+	rowErr bool
+
+	// needUnescape is set if the current row may contain escape chars.
 	needUnescape bool
+
+	// rbNeedUnescape is set if rb may contain escape chars.
+	rbNeedUnescape bool
+
+	// scratchNeedUnescape is set if scratch may contain escape chars.
+	scratchNeedUnescape bool
+
+	rowErrs     []error
+	skippedRows int
 }
 
 // Reset resets the reader for reading from r.
+//
+// Reader settings are preserved.
 func (tr *Reader) Reset(r io.Reader) {
 	tr.r = r
 	tr.rb = nil
@@ -54,8 +188,27 @@ func (tr *Reader) Reset(r io.Reader) {
 	tr.b = nil
 	tr.scratch = tr.scratch[:0]
 
+	tr.rawRowBuf = tr.rawRowBuf[:0]
+	tr.rowUnescaped = false
+
+	tr.colOffs = tr.colOffs[:0]
+	tr.colOffsValid = false
+	tr.colBuf = tr.colBuf[:0]
+
+	tr.header = tr.header[:0]
+	tr.proj = tr.proj[:0]
+	tr.projPos = 0
+	tr.projMax = 0
+	tr.colsCount = 0
+
 	tr.err = nil
+	tr.rowErr = false
 	tr.needUnescape = false
+	tr.rbNeedUnescape = false
+	tr.scratchNeedUnescape = false
+
+	tr.rowErrs = nil
+	tr.skippedRows = 0
 }
 
 // Error returns the last error.
@@ -69,6 +222,21 @@ func (tr *Reader) Error() error {
 // ResetError resets the current error, so the reader could proceed further.
 func (tr *Reader) ResetError() {
 	tr.err = nil
+	tr.rowErr = false
+}
+
+// RowErrors returns errors for rows skipped in lenient mode.
+//
+// See SkipInvalidRows for details.
+func (tr *Reader) RowErrors() []error {
+	return tr.rowErrs
+}
+
+// SkippedRows returns the number of rows skipped in lenient mode.
+//
+// See SkipInvalidRows for details.
+func (tr *Reader) SkippedRows() int {
+	return tr.skippedRows
 }
 
 // HasCols returns true if the current row contains unread columns.
@@ -78,45 +246,162 @@ func (tr *Reader) ResetError() {
 // This function may be used if TSV stream contains rows with different
 // number of colums.
 func (tr *Reader) HasCols() bool {
+	if len(tr.proj) > 0 {
+		// The next projected column may be missing in ragged rows.
+		return len(tr.rowBuf) > 0 && tr.projPos < len(tr.proj) && tr.proj[tr.projPos] < len(tr.colOffs)-1
+	}
 	return len(tr.rowBuf) > 0 && tr.b != nil
 }
 
+// Header returns column names read from the first row if HasHeader is set.
+//
+// The returned value is valid until Reset call.
+func (tr *Reader) Header() []string {
+	return tr.header
+}
+
+// RawRow returns the current row as it was read, i.e. without unescaping
+// and without the trailing newline.
+//
+// The returned value is valid until the next call to Next.
+func (tr *Reader) RawRow() []byte {
+	if tr.rowUnescaped {
+		return tr.rawRowBuf
+	}
+	return tr.rowBuf
+}
+
 // Next advances to the next row.
 //
 // Returns true if the next row does exist.
 //
-// Next must be called after reading all the columns on the previous row.
-// Check Error after Next returns false.
+// Next must be called after reading all the columns on the previous row
+// unless IgnoreUnreadCols is set. SkipRestCols may be used for skipping
+// the remaining columns. Check Error after Next returns false.
 //
 // HasCols may be used for reading rows with variable number of columns.
+//
+// Next skips the current row if it is invalid and SkipInvalidRows is set.
 func (tr *Reader) Next() bool {
+	for {
+		if !tr.readRow() {
+			return false
+		}
+		if tr.row == 1 {
+			if err := tr.initFirstRow(); err != nil {
+				tr.err = err
+				return false
+			}
+			if tr.HasHeader {
+				// The header has been read. Proceed to the first data row.
+				tr.projPos = len(tr.proj)
+				continue
+			}
+		}
+		if tr.colsCount > 0 {
+			if n := bytes.Count(tr.rowBuf, tabSep) + 1; n != tr.colsCount {
+				err := &ParseError{
+					Row:    tr.row,
+					Err:    fmt.Errorf("%w: %d; expecting %d", ErrColsCount, n, tr.colsCount),
+					rowBuf: append([]byte(nil), tr.rowBuf...),
+				}
+				if !tr.SkipInvalidRows {
+					tr.err = err
+					return false
+				}
+				if !tr.skipRow(err) {
+					return false
+				}
+				continue
+			}
+		}
+		if len(tr.proj) > 0 {
+			tr.b = nil
+			tr.projPos = 0
+			// Avoid int overflow for huge column indexes in projection.
+			tr.initColOffs(min(tr.projMax, math.MaxInt-1) + 1)
+		}
+		return true
+	}
+}
+
+var tabSep = []byte("\t")
+
+// initFirstRow initializes the reader state on the first row.
+//
+// The first row is read as a header if HasHeader is set.
+func (tr *Reader) initFirstRow() error {
+	tr.colsCount = tr.ColsCount
+	if tr.colsCount <= 0 && tr.FixedColsCount {
+		tr.colsCount = bytes.Count(tr.rowBuf, tabSep) + 1
+	}
+	if tr.HasHeader {
+		tr.readHeader()
+		if tr.colsCount > 0 && len(tr.header) != tr.colsCount {
+			return &ParseError{
+				Row:    tr.row,
+				Err:    fmt.Errorf("%w in the header: %d; expecting %d", ErrColsCount, len(tr.header), tr.colsCount),
+				rowBuf: append([]byte(nil), tr.RawRow()...),
+			}
+		}
+	}
+	return tr.initProjection()
+}
+
+func (tr *Reader) readRow() bool {
 	if tr.err != nil {
-		return false
+		if !tr.rowErr {
+			return false
+		}
+		if !tr.skipRow(tr.err) {
+			return false
+		}
 	}
-	if tr.HasCols() {
-		tr.err = fmt.Errorf("row #%d %q contains unread columns: %q", tr.row, tr.rowBuf, tr.b)
+	if !tr.IgnoreUnreadCols && tr.HasCols() {
+		err := &ParseError{
+			Row:    tr.row,
+			Value:  append([]byte(nil), tr.unreadCols()...),
+			Err:    ErrUnreadColumns,
+			rowBuf: append([]byte(nil), tr.RawRow()...),
+		}
+		tr.err = err
 		return false
 	}
 
 	tr.row++
 	tr.col = 0
 	tr.rowBuf = nil
+	tr.rowUnescaped = false
+	tr.colOffsValid = false
 
 	for {
 		if len(tr.rb) == 0 {
 			// Read buffer is empty. Attempt to fill it.
 			if tr.rErr != nil {
+				if tr.rErr == io.EOF && len(tr.scratch) > 0 && tr.AllowMissingNewline {
+					// Treat the remaining data as the last row.
+					tr.rowBuf = tr.scratch
+					tr.b = tr.rowBuf
+					tr.scratch = tr.scratch[:0]
+					tr.needUnescape = tr.scratchNeedUnescape
+					tr.scratchNeedUnescape = false
+					return true
+				}
 				tr.err = tr.rErr
 				if tr.err != io.EOF {
-					tr.err = fmt.Errorf("cannot read row #%d: %s", tr.row, tr.err)
+					tr.err = fmt.Errorf("cannot read row #%d: %w", tr.row, tr.err)
 				} else if len(tr.scratch) > 0 {
-					tr.err = fmt.Errorf("cannot find newline at the end of row #%d; row: %q", tr.row, tr.scratch)
+					tr.err = &ParseError{
+						Row:    tr.row,
+						Err:    ErrNoNewline,
+						rowBuf: append([]byte(nil), tr.scratch...),
+					}
 				}
 				return false
 			}
 			n, err := tr.r.Read(tr.rBuf[:])
 			tr.rb = tr.rBuf[:n]
-			tr.needUnescape = (bytes.IndexByte(tr.rb, '\\') >= 0)
+			tr.rbNeedUnescape = (bytes.IndexByte(tr.rb, '\\') >= 0)
 			tr.rErr = err
 		}
 
@@ -126,10 +411,15 @@ func (tr *Reader) Next() bool {
 			// Fast path: the row has been found.
 			b := tr.rb[:n]
 			tr.rb = tr.rb[n+1:]
+			tr.needUnescape = tr.rbNeedUnescape
 			if len(tr.scratch) > 0 {
+				// The row spans multiple read buffers, so it may contain
+				// escape chars in any of them.
 				tr.scratch = append(tr.scratch, b...)
 				b = tr.scratch
 				tr.scratch = tr.scratch[:0]
+				tr.needUnescape = tr.needUnescape || tr.scratchNeedUnescape
+				tr.scratchNeedUnescape = false
 			}
 			tr.rowBuf = b
 			tr.b = tr.rowBuf
@@ -139,10 +429,78 @@ func (tr *Reader) Next() bool {
 		// Slow path: cannot find the end of row.
 		// Append tr.rb to tr.scratch and repeat.
 		tr.scratch = append(tr.scratch, tr.rb...)
+		tr.scratchNeedUnescape = tr.scratchNeedUnescape || tr.rbNeedUnescape
 		tr.rb = nil
 	}
 }
 
+func (tr *Reader) readHeader() {
+	tr.header = tr.header[:0]
+	for tr.HasCols() {
+		b, _ := tr.nextCol()
+		tr.colBuf = appendUnescaped(tr.colBuf[:0], b)
+		tr.header = append(tr.header, string(tr.colBuf))
+	}
+}
+
+func (tr *Reader) initProjection() error {
+	tr.proj = tr.proj[:0]
+	tr.projMax = 0
+	if len(tr.Projection) > 0 && len(tr.ProjectionNames) > 0 {
+		return fmt.Errorf("Projection and ProjectionNames cannot be set simultaneously")
+	}
+	if len(tr.ProjectionNames) > 0 {
+		if !tr.HasHeader {
+			return fmt.Errorf("ProjectionNames requires HasHeader")
+		}
+		for _, name := range tr.ProjectionNames {
+			n := -1
+			for i, s := range tr.header {
+				if s == name {
+					n = i
+					break
+				}
+			}
+			if n < 0 {
+				return fmt.Errorf("cannot find column %q from ProjectionNames in the header %q", name, tr.header)
+			}
+			tr.proj = append(tr.proj, n)
+		}
+	} else {
+		tr.proj = append(tr.proj, tr.Projection...)
+	}
+
+	for i, n := range tr.proj {
+		if n < 0 {
+			return fmt.Errorf("invalid column index in Projection: %d; it cannot be negative", n)
+		}
+		for _, m := range tr.proj[:i] {
+			if m == n {
+				return fmt.Errorf("duplicate column #%d in projection", n+1)
+			}
+		}
+		if n > tr.projMax {
+			tr.projMax = n
+		}
+	}
+	return nil
+}
+
+// unreadCols returns unread columns from the current row.
+func (tr *Reader) unreadCols() []byte {
+	if len(tr.proj) == 0 {
+		return tr.b
+	}
+	if tr.projPos >= len(tr.proj) {
+		return nil
+	}
+	n := tr.proj[tr.projPos]
+	if n >= len(tr.colOffs)-1 {
+		return nil
+	}
+	return tr.rowBuf[tr.colOffs[n]:]
+}
+
 // Int returns the next int column value from the current row.
 func (tr *Reader) Int() int {
 	if tr.err != nil {
@@ -150,13 +508,16 @@ func (tr *Reader) Int() int {
 	}
 	b, err := tr.nextCol()
 	if err != nil {
-		tr.setColError("cannot read `int`", err)
+		tr.setColError("cannot read", "int", nil, err)
+		return 0
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
 		return 0
 	}
 
 	n, err := strconv.Atoi(b2s(b))
 	if err != nil {
-		tr.setColError("cannot parse `int`", err)
+		tr.setColError("cannot parse", "int", b, err)
 		return 0
 	}
 	return n
@@ -169,7 +530,10 @@ func (tr *Reader) Uint() uint {
 	}
 	b, err := tr.nextCol()
 	if err != nil {
-		tr.setColError("cannot read `uint`", err)
+		tr.setColError("cannot read", "uint", nil, err)
+		return 0
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
 		return 0
 	}
 	s := b2s(b)
@@ -183,7 +547,7 @@ func (tr *Reader) Uint() uint {
 	// Slow path - use ParseUint
 	nu, err := strconv.ParseUint(s, 10, strconv.IntSize)
 	if err != nil {
-		tr.setColError("cannot parse `uint`", err)
+		tr.setColError("cannot parse", "uint", b, err)
 		return 0
 	}
 	return uint(nu)
@@ -196,7 +560,10 @@ func (tr *Reader) Int32() int32 {
 	}
 	b, err := tr.nextCol()
 	if err != nil {
-		tr.setColError("cannot read `int32`", err)
+		tr.setColError("cannot read", "int32", nil, err)
+		return 0
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
 		return 0
 	}
 	s := b2s(b)
@@ -210,7 +577,7 @@ func (tr *Reader) Int32() int32 {
 	// Slow path - use ParseInt
 	n32, err := strconv.ParseInt(s, 10, 32)
 	if err != nil {
-		tr.setColError("cannot parse `int32`", err)
+		tr.setColError("cannot parse", "int32", b, err)
 		return 0
 	}
 	return int32(n32)
@@ -223,7 +590,10 @@ func (tr *Reader) Uint32() uint32 {
 	}
 	b, err := tr.nextCol()
 	if err != nil {
-		tr.setColError("cannot read `uint32`", err)
+		tr.setColError("cannot read", "uint32", nil, err)
+		return 0
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
 		return 0
 	}
 	s := b2s(b)
@@ -237,7 +607,7 @@ func (tr *Reader) Uint32() uint32 {
 	// Slow path - use ParseUint
 	n32, err := strconv.ParseUint(s, 10, 32)
 	if err != nil {
-		tr.setColError("cannot parse `uint32`", err)
+		tr.setColError("cannot parse", "uint32", b, err)
 		return 0
 	}
 	return uint32(n32)
@@ -250,16 +620,19 @@ func (tr *Reader) Int16() int16 {
 	}
 	b, err := tr.nextCol()
 	if err != nil {
-		tr.setColError("cannot read `int16`", err)
+		tr.setColError("cannot read", "int16", nil, err)
+		return 0
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
 		return 0
 	}
 	n, err := strconv.Atoi(b2s(b))
 	if err != nil {
-		tr.setColError("cannot parse `int16`", err)
+		tr.setColError("cannot parse", "int16", b, err)
 		return 0
 	}
 	if n < math.MinInt16 || n > math.MaxInt16 {
-		tr.setColError("cannot parse `int16`", fmt.Errorf("out of range"))
+		tr.setColError("cannot parse", "int16", b, errRange)
 		return 0
 	}
 	return int16(n)
@@ -272,20 +645,23 @@ func (tr *Reader) Uint16() uint16 {
 	}
 	b, err := tr.nextCol()
 	if err != nil {
-		tr.setColError("cannot read `uint16`", err)
+		tr.setColError("cannot read", "uint16", nil, err)
+		return 0
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
 		return 0
 	}
 	n, err := strconv.Atoi(b2s(b))
 	if err != nil {
-		tr.setColError("cannot parse `uint16`", err)
+		tr.setColError("cannot parse", "uint16", b, err)
 		return 0
 	}
 	if n < 0 {
-		tr.setColError("cannot parse `uint16`", fmt.Errorf("invalid syntax"))
+		tr.setColError("cannot parse", "uint16", b, strconv.ErrSyntax)
 		return 0
 	}
 	if n > math.MaxUint16 {
-		tr.setColError("cannot parse `uint16`", fmt.Errorf("out of range"))
+		tr.setColError("cannot parse", "uint16", b, errRange)
 		return 0
 	}
 	return uint16(n)
@@ -298,16 +674,19 @@ func (tr *Reader) Int8() int8 {
 	}
 	b, err := tr.nextCol()
 	if err != nil {
-		tr.setColError("cannot read `int8`", err)
+		tr.setColError("cannot read", "int8", nil, err)
+		return 0
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
 		return 0
 	}
 	n, err := strconv.Atoi(b2s(b))
 	if err != nil {
-		tr.setColError("cannot parse `int8`", err)
+		tr.setColError("cannot parse", "int8", b, err)
 		return 0
 	}
 	if n < math.MinInt8 || n > math.MaxInt8 {
-		tr.setColError("cannot parse `int8`", fmt.Errorf("out of range"))
+		tr.setColError("cannot parse", "int8", b, errRange)
 		return 0
 	}
 	return int8(n)
@@ -320,20 +699,23 @@ func (tr *Reader) Uint8() uint8 {
 	}
 	b, err := tr.nextCol()
 	if err != nil {
-		tr.setColError("cannot read `uint8`", err)
+		tr.setColError("cannot read", "uint8", nil, err)
+		return 0
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
 		return 0
 	}
 	n, err := strconv.Atoi(b2s(b))
 	if err != nil {
-		tr.setColError("cannot parse `uint8`", err)
+		tr.setColError("cannot parse", "uint8", b, err)
 		return 0
 	}
 	if n < 0 {
-		tr.setColError("cannot parse `uint8`", fmt.Errorf("invalid syntax"))
+		tr.setColError("cannot parse", "uint8", b, strconv.ErrSyntax)
 		return 0
 	}
 	if n > math.MaxUint8 {
-		tr.setColError("cannot parse `uint8`", fmt.Errorf("out of range"))
+		tr.setColError("cannot parse", "uint8", b, errRange)
 		return 0
 	}
 	return uint8(n)
@@ -346,24 +728,30 @@ func (tr *Reader) Int64() int64 {
 	}
 	b, err := tr.nextCol()
 	if err != nil {
-		tr.setColError("cannot read `int64`", err)
+		tr.setColError("cannot read", "int64", nil, err)
 		return 0
 	}
-	s := b2s(b)
+	if len(b) == 0 && tr.EmptyAsDefault {
+		return 0
+	}
+	n, err := parseInt64(b2s(b))
+	if err != nil {
+		tr.setColError("cannot parse", "int64", b, err)
+		return 0
+	}
+	return n
+}
 
+// parseInt64 parses decimal int64 from s.
+func parseInt64(s string) (int64, error) {
 	// Fast path - attempt to use Atoi
 	n, err := strconv.Atoi(s)
 	if err == nil && int64(n) >= math.MinInt64 && int64(n) <= math.MaxInt64 {
-		return int64(n)
+		return int64(n), nil
 	}
 
 	// Slow path - use ParseInt
-	n64, err := strconv.ParseInt(s, 10, 64)
-	if err != nil {
-		tr.setColError("cannot parse `int64`", err)
-		return 0
-	}
-	return n64
+	return strconv.ParseInt(s, 10, 64)
 }
 
 // Uint64 returns the next uint64 column value from the current row.
@@ -373,7 +761,10 @@ func (tr *Reader) Uint64() uint64 {
 	}
 	b, err := tr.nextCol()
 	if err != nil {
-		tr.setColError("cannot read `uint64`", err)
+		tr.setColError("cannot read", "uint64", nil, err)
+		return 0
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
 		return 0
 	}
 	s := b2s(b)
@@ -387,7 +778,7 @@ func (tr *Reader) Uint64() uint64 {
 	// Slow path - use ParseUint
 	n64, err := strconv.ParseUint(s, 10, 64)
 	if err != nil {
-		tr.setColError("cannot parse `uint64`", err)
+		tr.setColError("cannot parse", "uint64", b, err)
 		return 0
 	}
 	return n64
@@ -400,14 +791,17 @@ func (tr *Reader) Float32() float32 {
 	}
 	b, err := tr.nextCol()
 	if err != nil {
-		tr.setColError("cannot read `float32`", err)
+		tr.setColError("cannot read", "float32", nil, err)
+		return 0
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
 		return 0
 	}
 	s := b2s(b)
 
 	f32, err := strconv.ParseFloat(s, 32)
 	if err != nil {
-		tr.setColError("cannot parse `float32`", err)
+		tr.setColError("cannot parse", "float32", b, err)
 		return 0
 	}
 	return float32(f32)
@@ -420,14 +814,17 @@ func (tr *Reader) Float64() float64 {
 	}
 	b, err := tr.nextCol()
 	if err != nil {
-		tr.setColError("cannot read `float64`", err)
+		tr.setColError("cannot read", "float64", nil, err)
+		return 0
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
 		return 0
 	}
 	s := b2s(b)
 
 	f64, err := strconv.ParseFloat(s, 64)
 	if err != nil {
-		tr.setColError("cannot parse `float64`", err)
+		tr.setColError("cannot parse", "float64", b, err)
 		return 0
 	}
 	return f64
@@ -440,10 +837,140 @@ func (tr *Reader) SkipCol() {
 	}
 	_, err := tr.nextCol()
 	if err != nil {
-		tr.setColError("cannot skip column", err)
+		tr.setColError("cannot skip column", "", nil, err)
+	}
+}
+
+// SkipCols skips the next n columns from the current row.
+//
+// SkipCols is equivalent to calling SkipCol n times, but it avoids
+// the per-call overhead. See BenchmarkReaderSkipCols.
+func (tr *Reader) SkipCols(n int) {
+	if tr.err != nil {
+		return
+	}
+	if tr.row == 0 {
+		tr.setColError("cannot skip column", "", nil, errMissingNext)
+		return
+	}
+	if len(tr.proj) > 0 {
+		for ; n > 0; n-- {
+			if _, err := tr.nextProjectedCol(); err != nil {
+				tr.setColError("cannot skip column", "", nil, err)
+				return
+			}
+		}
+		return
+	}
+	for ; n > 0; n-- {
+		tr.col++
+		if tr.b == nil {
+			tr.setColError("cannot skip column", "", nil, ErrNoMoreColumns)
+			return
+		}
+		i := bytes.IndexByte(tr.b, '\t')
+		if i < 0 {
+			tr.b = nil
+		} else {
+			tr.b = tr.b[i+1:]
+		}
 	}
 }
 
+// SkipRestCols skips the remaining columns in the current row.
+//
+// This allows reading only the first columns in the row without
+// ErrUnreadColumns error in Next. See also IgnoreUnreadCols.
+func (tr *Reader) SkipRestCols() {
+	if tr.err != nil {
+		return
+	}
+	tr.b = nil
+	tr.projPos = len(tr.proj)
+}
+
+// SkipNull skips the next column and returns true if it contains NULL,
+// i.e. \N. Empty column is treated as NULL if EmptyAsDefault is set.
+//
+// The next column isn't skipped if it doesn't contain NULL, so it may be
+// read with the appropriate accessor.
+func (tr *Reader) SkipNull() bool {
+	b := tr.PeekBytes()
+	if b == nil {
+		return false
+	}
+	if string(b) == `\N` || len(b) == 0 && tr.EmptyAsDefault {
+		tr.SkipCol()
+		return true
+	}
+	return false
+}
+
+// Col returns the column value with the given index from the current row.
+//
+// Column indexes start from 0. Columns may be accessed in any order.
+// The first call to Col switches the current row to random access mode,
+// so the remaining columns cannot be read sequentially after that.
+//
+// The returned value is valid until the next call to Reader.
+func (tr *Reader) Col(i int) []byte {
+	if tr.err != nil {
+		return nil
+	}
+	if tr.row == 0 {
+		tr.setColError("cannot read", "bytes", nil, errMissingNext)
+		return nil
+	}
+	if !tr.colOffsValid {
+		tr.initColOffs(math.MaxInt)
+	}
+	tr.b = nil
+	tr.projPos = len(tr.proj)
+	tr.col = i + 1
+	if i < 0 || i >= len(tr.colOffs)-1 {
+		tr.setColError("cannot read", "bytes", nil, ErrNoMoreColumns)
+		return nil
+	}
+	b := tr.RawRow()[tr.colOffs[i] : tr.colOffs[i+1]-1]
+	return tr.unescapeCol(b)
+}
+
+// unescapeCol returns unescaped b without modifying b.
+//
+// The returned value is valid until the next call to Reader.
+func (tr *Reader) unescapeCol(b []byte) []byte {
+	if !tr.needUnescape || bytes.IndexByte(b, '\\') < 0 {
+		return b
+	}
+	tr.colBuf = appendUnescaped(tr.colBuf[:0], b)
+	return tr.colBuf
+}
+
+// initColOffs builds column offsets index for up to maxCols first columns
+// of the current row.
+//
+// colOffs[i] contains the start offset for the i-th column. The end offset
+// for the i-th column is colOffs[i+1]-1. The last item contains len(row)+1
+// if all the columns are located.
+func (tr *Reader) initColOffs(maxCols int) {
+	b := tr.RawRow()
+	offs := tr.colOffs[:0]
+	offs = append(offs, 0)
+	n := 0
+	for len(offs) <= maxCols {
+		i := bytes.IndexByte(b[n:], '\t')
+		if i < 0 {
+			tr.colOffs = append(offs, len(b)+1)
+			tr.colOffsValid = true
+			return
+		}
+		n += i + 1
+		offs = append(offs, n)
+	}
+	tr.colOffs = offs
+	tr.colOffsValid = false
+}
+
 // Bytes returns the next bytes column value from the current row.
 //
 // The returned value is valid until the next call to Reader.
@@ -453,7 +980,7 @@ func (tr *Reader) Bytes() []byte {
 	}
 	b, err := tr.nextCol()
 	if err != nil {
-		tr.setColError("cannot read `bytes`", err)
+		tr.setColError("cannot read", "bytes", nil, err)
 		return nil
 	}
 
@@ -470,51 +997,121 @@ func (tr *Reader) Bytes() []byte {
 	}
 
 	// Slow path - in-place unescaping compatible with ClickHouse.
-	n++
-	d := b[:n]
-	b = b[n:]
-	for len(b) > 0 {
-		switch b[0] {
-		case 'b':
-			d[len(d)-1] = '\b'
-		case 'f':
-			d[len(d)-1] = '\f'
-		case 'r':
-			d[len(d)-1] = '\r'
-		case 'n':
-			d[len(d)-1] = '\n'
-		case 't':
-			d[len(d)-1] = '\t'
-		case '0':
-			d[len(d)-1] = 0
-		case '\'':
-			d[len(d)-1] = '\''
-		case '\\':
-			d[len(d)-1] = '\\'
-		default:
-			d[len(d)-1] = b[0]
-		}
+	if !tr.rowUnescaped {
+		// Preserve the original row before modifying it.
+		tr.rawRowBuf = append(tr.rawRowBuf[:0], tr.rowBuf...)
+		tr.rowUnescaped = true
+	}
+	return appendUnescaped(b[:n], b[n:])
+}
 
-		b = b[1:]
-		n = bytes.IndexByte(b, '\\')
-		if n < 0 {
-			d = append(d, b...)
-			break
+// RawBytes returns the next bytes column value from the current row
+// without unescaping.
+//
+// The returned value is valid until the next call to Reader.
+func (tr *Reader) RawBytes() []byte {
+	if tr.err != nil {
+		return nil
+	}
+	b, err := tr.nextCol()
+	if err != nil {
+		tr.setColError("cannot read", "bytes", nil, err)
+		return nil
+	}
+	return b
+}
+
+// PeekBytes returns the next column value from the current row without
+// unescaping and without advancing to the next column.
+//
+// nil is returned if the current row has no more columns.
+//
+// The returned value is valid until the next call to Reader.
+func (tr *Reader) PeekBytes() []byte {
+	if tr.err != nil {
+		return nil
+	}
+	if len(tr.proj) > 0 {
+		if tr.projPos >= len(tr.proj) {
+			return nil
 		}
-		n++
-		d = append(d, b[:n]...)
-		b = b[n:]
+		n := tr.proj[tr.projPos]
+		if n >= len(tr.colOffs)-1 {
+			return nil
+		}
+		return tr.rowBuf[tr.colOffs[n] : tr.colOffs[n+1]-1]
 	}
-	return d
+	if tr.b == nil {
+		return nil
+	}
+	n := bytes.IndexByte(tr.b, '\t')
+	if n < 0 {
+		return tr.b
+	}
+	return tr.b[:n]
 }
 
 // String returns the next string column value from the current row.
 //
-// String allocates memory. Use Bytes to avoid memory allocations.
+// String allocates memory. Use Bytes or UnsafeString to avoid memory allocations.
 func (tr *Reader) String() string {
 	return string(tr.Bytes())
 }
 
+// UnsafeString returns the next string column value from the current row
+// without memory allocations.
+//
+// The returned string refers to the row buffer, so it is valid only until
+// the next call to Next. It must not be retained after that, since the
+// buffer is overwritten with subsequent rows. Use String for values
+// that outlive the current row.
+func (tr *Reader) UnsafeString() string {
+	return b2s(tr.Bytes())
+}
+
+// AppendBytes appends the next unescaped column value from the current row
+// to dst and returns the extended dst.
+//
+// Unlike Bytes, the returned value remains valid after subsequent calls
+// to Reader, since it is stored in dst. The current row isn't modified.
+func (tr *Reader) AppendBytes(dst []byte) []byte {
+	if tr.err != nil {
+		return dst
+	}
+	b, err := tr.nextCol()
+	if err != nil {
+		tr.setColError("cannot read", "bytes", nil, err)
+		return dst
+	}
+	if !tr.needUnescape {
+		// Fast path - nothing to unescape.
+		return append(dst, b...)
+	}
+	return appendUnescaped(dst, b)
+}
+
+// Text reads the next column from the current row into u.
+//
+// u receives the unescaped column value, which must not be retained
+// after UnmarshalText returns.
+func (tr *Reader) Text(u encoding.TextUnmarshaler) {
+	if tr.err != nil {
+		return
+	}
+	b, err := tr.nextCol()
+	if err != nil {
+		tr.setColError("cannot read", typeName(u), nil, err)
+		return
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
+		setZero(u)
+		return
+	}
+	if err := u.UnmarshalText(tr.unescapeCol(b)); err != nil {
+		tr.setColError("cannot parse", typeName(u), b, err)
+	}
+}
+
 // Date returns the next date column value from the current row.
 //
 // date must be in the format YYYY-MM-DD
@@ -524,14 +1121,17 @@ func (tr *Reader) Date() time.Time {
 	}
 	b, err := tr.nextCol()
 	if err != nil {
-		tr.setColError("cannot read `date`", err)
+		tr.setColError("cannot read", "date", nil, err)
+		return zeroTime
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
 		return zeroTime
 	}
 	s := b2s(b)
 
-	y, m, d, err := parseDate(s)
+	y, m, d, err := parseDate(s, tr.StrictDates)
 	if err != nil {
-		tr.setColError("cannot parse `date`", err)
+		tr.setColError("cannot parse", "date", b, err)
 		return zeroTime
 	}
 	if y == 0 && m == 0 && d == 0 {
@@ -550,45 +1150,37 @@ func (tr *Reader) DateTime() time.Time {
 	}
 	b, err := tr.nextCol()
 	if err != nil {
-		tr.setColError("cannot read `datetime`", err)
+		tr.setColError("cannot read", "datetime", nil, err)
+		return zeroTime
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
 		return zeroTime
 	}
 	s := b2s(b)
 
-	dt, err := parseDateTime(s)
+	dt, err := parseDateTime(s, tr.StrictDates)
 	if err != nil {
-		tr.setColError("cannot parse `datetime`", err)
+		tr.setColError("cannot parse", "datetime", b, err)
 		return zeroTime
 	}
 	return dt
 }
 
-func parseDateTime(s string) (time.Time, error) {
+func parseDateTime(s string, strict bool) (time.Time, error) {
 	if len(s) != len("YYYY-MM-DD hh:mm:ss") {
 		return zeroTime, fmt.Errorf("too short datetime")
 	}
-	y, m, d, err := parseDate(s[:len("YYYY-MM-DD")])
+	y, m, d, err := parseDate(s[:len("YYYY-MM-DD")], strict)
 	if err != nil {
 		return zeroTime, err
 	}
 	s = s[len("YYYY-MM-DD"):]
-	if s[0] != ' ' || s[3] != ':' || s[6] != ':' {
+	if s[0] != ' ' {
 		return zeroTime, fmt.Errorf("invalid time format. Must be hh:mm:ss")
 	}
-	hS := s[1:3]
-	minS := s[4:6]
-	secS := s[7:]
-	h, err := strconv.Atoi(hS)
+	h, min, sec, err := parseClock(s[1:], strict)
 	if err != nil {
-		return zeroTime, fmt.Errorf("invalid hour: %s", err)
-	}
-	min, err := strconv.Atoi(minS)
-	if err != nil {
-		return zeroTime, fmt.Errorf("invalid minute: %s", err)
-	}
-	sec, err := strconv.Atoi(secS)
-	if err != nil {
-		return zeroTime, fmt.Errorf("invalid second: %s", err)
+		return zeroTime, err
 	}
 	if y == 0 && m == 0 && d == 0 {
 		// Special case for ClickHouse
@@ -597,13 +1189,49 @@ func parseDateTime(s string) (time.Time, error) {
 	return time.Date(y, time.Month(m), d, h, min, sec, 0, time.UTC), nil
 }
 
-func parseDate(s string) (y, m, d int, err error) {
+// parseClock parses time of day in the format hh:mm:ss.
+func parseClock(s string, strict bool) (h, min, sec int, err error) {
+	if len(s) != len("hh:mm:ss") || s[2] != ':' || s[5] != ':' {
+		err = fmt.Errorf("invalid time format. Must be hh:mm:ss")
+		return
+	}
+	hS := s[:2]
+	minS := s[3:5]
+	secS := s[6:]
+	h, err = strconv.Atoi(hS)
+	if err != nil {
+		err = fmt.Errorf("invalid hour: %w", err)
+		return
+	}
+	min, err = strconv.Atoi(minS)
+	if err != nil {
+		err = fmt.Errorf("invalid minute: %w", err)
+		return
+	}
+	sec, err = strconv.Atoi(secS)
+	if err != nil {
+		err = fmt.Errorf("invalid second: %w", err)
+		return
+	}
+	if strict {
+		if !isDigits(hS) || !isDigits(minS) || !isDigits(secS) {
+			err = fmt.Errorf("invalid time format. Must be hh:mm:ss")
+			return
+		}
+		if err = checkTime(h, min, sec); err != nil {
+			return
+		}
+	}
+	return h, min, sec, nil
+}
+
+func parseDate(s string, strict bool) (y, m, d int, err error) {
 	if len(s) != len("YYYY-MM-DD") {
 		err = fmt.Errorf("too short date")
 		return
 	}
 	s = s[:len("YYYY-MM-DD")]
-	if s[4] != '-' && s[7] != '-' {
+	if s[4] != '-' || s[7] != '-' {
 		err = fmt.Errorf("invalid date format. Must be YYYY-MM-DD")
 		return
 	}
@@ -612,32 +1240,93 @@ func parseDate(s string) (y, m, d int, err error) {
 	dS := s[8:]
 	y, err = strconv.Atoi(yS)
 	if err != nil {
-		err = fmt.Errorf("invalid year: %s", err)
+		err = fmt.Errorf("invalid year: %w", err)
 		return
 	}
 	m, err = strconv.Atoi(mS)
 	if err != nil {
-		err = fmt.Errorf("invalid month: %s", err)
+		err = fmt.Errorf("invalid month: %w", err)
 		return
 	}
 	d, err = strconv.Atoi(dS)
 	if err != nil {
-		err = fmt.Errorf("invalid day: %s", err)
+		err = fmt.Errorf("invalid day: %w", err)
 		return
 	}
+	if strict {
+		if !isDigits(yS) || !isDigits(mS) || !isDigits(dS) {
+			err = fmt.Errorf("invalid date format. Must be YYYY-MM-DD")
+			return
+		}
+		if err = checkDate(y, m, d); err != nil {
+			return
+		}
+	}
 	return y, m, d, nil
 }
 
+// checkDate verifies whether y, m and d form a valid date.
+//
+// 0000-00-00 is valid, since it is used by ClickHouse for zero dates.
+func checkDate(y, m, d int) error {
+	if y == 0 && m == 0 && d == 0 {
+		return nil
+	}
+	if m < 1 || m > 12 {
+		return fmt.Errorf("month out of range: %d", m)
+	}
+	if d < 1 || d > daysInMonth(y, m) {
+		return fmt.Errorf("day out of range: %d", d)
+	}
+	return nil
+}
+
+func daysInMonth(y, m int) int {
+	if m == 2 && y%4 == 0 && (y%100 != 0 || y%400 == 0) {
+		return 29
+	}
+	return daysPerMonth[m-1]
+}
+
+var daysPerMonth = [12]int{31, 28, 31, 30, 31, 30, 31, 31, 30, 31, 30, 31}
+
+// checkTime verifies whether h, min and sec form a valid time of day.
+func checkTime(h, min, sec int) error {
+	if h < 0 || h > 23 {
+		return fmt.Errorf("hour out of range: %d", h)
+	}
+	if min < 0 || min > 59 {
+		return fmt.Errorf("minute out of range: %d", min)
+	}
+	if sec < 0 || sec > 59 {
+		return fmt.Errorf("second out of range: %d", sec)
+	}
+	return nil
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 var zeroTime time.Time
 
 func (tr *Reader) nextCol() ([]byte, error) {
 	if tr.row == 0 {
-		return nil, fmt.Errorf("missing Next call")
+		return nil, errMissingNext
+	}
+
+	if len(tr.proj) > 0 {
+		return tr.nextProjectedCol()
 	}
 
 	tr.col++
 	if tr.b == nil {
-		return nil, fmt.Errorf("no more columns")
+		return nil, ErrNoMoreColumns
 	}
 
 	n := bytes.IndexByte(tr.b, '\t')
@@ -653,8 +1342,142 @@ func (tr *Reader) nextCol() ([]byte, error) {
 	return b, nil
 }
 
-func (tr *Reader) setColError(msg string, err error) {
-	tr.err = fmt.Errorf("%s at row #%d, col #%d %q: %s", msg, tr.row, tr.col, tr.rowBuf, err)
+// skipRow skips the current invalid row in lenient mode.
+//
+// false is returned if the row cannot be written to RejectedRowsWriter.
+func (tr *Reader) skipRow(err error) bool {
+	tr.err = nil
+	tr.rowErr = false
+	tr.b = nil
+	tr.projPos = len(tr.proj)
+	tr.skippedRows++
+
+	if tr.RejectedRowsWriter != nil {
+		b := appendEscaped(tr.rejectBuf[:0], err.Error())
+		b = append(b, '\t')
+		b = append(b, tr.RawRow()...)
+		b = append(b, '\n')
+		tr.rejectBuf = b
+		if _, werr := tr.RejectedRowsWriter.Write(b); werr != nil {
+			tr.err = fmt.Errorf("cannot write rejected row #%d: %w", tr.row, werr)
+			return false
+		}
+	}
+
+	if tr.RowErrorHandler != nil {
+		tr.RowErrorHandler(err)
+		return true
+	}
+	maxRowErrors := tr.MaxRowErrors
+	if maxRowErrors <= 0 {
+		maxRowErrors = defaultMaxRowErrors
+	}
+	if len(tr.rowErrs) < maxRowErrors {
+		tr.rowErrs = append(tr.rowErrs, err)
+	}
+	return true
+}
+
+const defaultMaxRowErrors = 100
+
+func (tr *Reader) nextProjectedCol() ([]byte, error) {
+	if tr.projPos >= len(tr.proj) {
+		tr.col++
+		return nil, ErrNoMoreColumns
+	}
+	n := tr.proj[tr.projPos]
+	tr.projPos++
+	tr.col = n + 1
+	if n >= len(tr.colOffs)-1 {
+		return nil, ErrNoMoreColumns
+	}
+	return tr.rowBuf[tr.colOffs[n] : tr.colOffs[n+1]-1], nil
+}
+
+func (tr *Reader) setColError(msg, typ string, b []byte, err error) {
+	tr.err = &ParseError{
+		Row:    tr.row,
+		Col:    tr.col,
+		Type:   typ,
+		Value:  append([]byte(nil), b...),
+		Err:    err,
+		msg:    msg,
+		rowBuf: append([]byte(nil), tr.RawRow()...),
+	}
+	tr.rowErr = tr.SkipInvalidRows && tr.row > 0
+}
+
+// appendUnescaped appends ClickHouse-compatible unescaped b to dst.
+//
+// dst may share the underlying memory with b if dst ends at the start of b.
+// This allows in-place unescaping.
+func appendUnescaped(dst, b []byte) []byte {
+	n := bytes.IndexByte(b, '\\')
+	if n < 0 {
+		return append(dst, b...)
+	}
+	n++
+	d := append(dst, b[:n]...)
+	b = b[n:]
+	for len(b) > 0 {
+		switch b[0] {
+		case 'b':
+			d[len(d)-1] = '\b'
+		case 'f':
+			d[len(d)-1] = '\f'
+		case 'r':
+			d[len(d)-1] = '\r'
+		case 'n':
+			d[len(d)-1] = '\n'
+		case 't':
+			d[len(d)-1] = '\t'
+		case '0':
+			d[len(d)-1] = 0
+		case '\'':
+			d[len(d)-1] = '\''
+		case '\\':
+			d[len(d)-1] = '\\'
+		default:
+			d[len(d)-1] = b[0]
+		}
+
+		b = b[1:]
+		n = bytes.IndexByte(b, '\\')
+		if n < 0 {
+			d = append(d, b...)
+			break
+		}
+		n++
+		d = append(d, b[:n]...)
+		b = b[n:]
+	}
+	return d
+}
+
+// appendEscaped appends s escaped in ClickHouse-compatible way to dst.
+func appendEscaped(dst []byte, s string) []byte {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '\b':
+			dst = append(dst, '\\', 'b')
+		case '\f':
+			dst = append(dst, '\\', 'f')
+		case '\r':
+			dst = append(dst, '\\', 'r')
+		case '\n':
+			dst = append(dst, '\\', 'n')
+		case '\t':
+			dst = append(dst, '\\', 't')
+		case 0:
+			dst = append(dst, '\\', '0')
+		case '\\':
+			dst = append(dst, '\\', '\\')
+		default:
+			dst = append(dst, c)
+		}
+	}
+	return dst
 }
 
 func b2s(b []byte) string {
@@ -2,6 +2,7 @@ package tsvreader
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"math"
@@ -256,6 +257,38 @@ func testReaderNoNewline(t *testing.T, s string) {
 	}
 }
 
+func TestReaderAllowMissingNewline(t *testing.T) {
+	testReaderAllowMissingNewline(t, "foobar", []string{"foobar"})
+	testReaderAllowMissingNewline(t, "foo\t", []string{"foo", ""})
+	testReaderAllowMissingNewline(t, "\t", []string{"", ""})
+	testReaderAllowMissingNewline(t, "\tfoo\t\tbar", []string{"", "foo", "", "bar"})
+	testReaderAllowMissingNewline(t, "foo\x00bar", []string{"foo\x00bar"})
+	testReaderAllowMissingNewline(t, "foo\\tbar", []string{"foo\tbar"})
+	testReaderAllowMissingNewline(t, "foo\nbar\tbaz", []string{"foo"}, []string{"bar", "baz"})
+	testReaderAllowMissingNewline(t, "foo\nbar\\n\n", []string{"foo"}, []string{"bar\n"})
+}
+
+func testReaderAllowMissingNewline(t *testing.T, s string, expected ...[]string) {
+	t.Helper()
+
+	b := &slowSource{
+		s: []byte(s),
+	}
+	r := New(b)
+	r.AllowMissingNewline = true
+	testReaderMultiRowsCols(t, r, expected)
+
+	// Make sure r.Next() returns false on subsequent calls.
+	for i := 0; i < 10; i++ {
+		if r.Next() {
+			t.Fatalf("Next must return false at the end of data; s: %q", s)
+		}
+		if err := r.Error(); err != nil {
+			t.Fatalf("unexpected error at the end of data: %s; s: %q", err, s)
+		}
+	}
+}
+
 func TestReaderReset(t *testing.T) {
 	var r Reader
 
@@ -1384,6 +1417,9 @@ func TestReaderDateFailure(t *testing.T) {
 	testReaderDateFailure(t, "2017-bb-aa")
 	testReaderDateFailure(t, "20cc-1b-3a")
 	testReaderDateFailure(t, "2017-10-10 ")
+	testReaderDateFailure(t, "2017/10/13")
+	testReaderDateFailure(t, "2017-10/13")
+	testReaderDateFailure(t, "2017/10-13")
 }
 
 func testReaderDateFailure(t *testing.T, date string) {
@@ -1511,3 +1547,1076 @@ func testReaderString(t *testing.T, before, after string) {
 		t.Fatalf("unexpected unescaped result: %q. Expecting %q", s, after)
 	}
 }
+
+func TestReaderUnsafeString(t *testing.T) {
+	b := bytes.NewBufferString("foo\ta\\tb\t\n")
+	r := New(b)
+	r.Next()
+	m := map[string]int{
+		"foo":  1,
+		"a\tb": 2,
+		"":     3,
+	}
+	for _, expected := range []int{1, 2, 3} {
+		s := r.UnsafeString()
+		if n := m[s]; n != expected {
+			t.Fatalf("unexpected value for %q: %d. Expecting %d", s, n, expected)
+		}
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if s := r.UnsafeString(); s != "" {
+		t.Fatalf("unexpected non-empty string: %q", s)
+	}
+	if err := r.Error(); !errors.Is(err, ErrNoMoreColumns) {
+		t.Fatalf("expecting ErrNoMoreColumns; got %v", err)
+	}
+}
+
+func TestReaderUnsafeStringNoAllocs(t *testing.T) {
+	data := []byte("foo\tb\\tar\n")
+	br := bytes.NewReader(data)
+	r := New(br)
+	m := map[string]int{
+		"foo":   1,
+		"b\tar": 2,
+	}
+	n := testing.AllocsPerRun(100, func() {
+		br.Reset(data)
+		r.Reset(br)
+		r.Next()
+		if m[r.UnsafeString()] != 1 || m[r.UnsafeString()] != 2 {
+			panic("unexpected map lookup result")
+		}
+	})
+	if n != 0 {
+		t.Fatalf("unexpected number of allocations: %v. Expecting 0", n)
+	}
+}
+
+func TestReaderAppendBytes(t *testing.T) {
+	b := bytes.NewBufferString("foo\tb\\ta\\nr\t\n1\t2\n")
+	r := New(b)
+
+	var dst []byte
+	var ss [][]byte
+	for r.Next() {
+		for r.HasCols() {
+			n := len(dst)
+			dst = r.AppendBytes(dst)
+			ss = append(ss, dst[n:len(dst):len(dst)])
+		}
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if string(dst) != "foob\ta\nr12" {
+		t.Fatalf("unexpected result: %q. Expecting %q", dst, "foob\ta\nr12")
+	}
+	expected := []string{"foo", "b\ta\nr", "", "1", "2"}
+	if len(ss) != len(expected) {
+		t.Fatalf("unexpected number of values: %d. Expecting %d", len(ss), len(expected))
+	}
+	for i, s := range ss {
+		if string(s) != expected[i] {
+			t.Fatalf("unexpected value #%d: %q. Expecting %q", i, s, expected[i])
+		}
+	}
+}
+
+func TestReaderAppendBytesUnescape(t *testing.T) {
+	b := bytes.NewBufferString("a\\tb\\\\\\0\tx\n")
+	r := New(b)
+	r.Next()
+	dst := r.AppendBytes([]byte("prefix:"))
+	if string(dst) != "prefix:a\tb\\\x00" {
+		t.Fatalf("unexpected result: %q. Expecting %q", dst, "prefix:a\tb\\\x00")
+	}
+	if rawRow := r.RawRow(); string(rawRow) != "a\\tb\\\\\\0\tx" {
+		t.Fatalf("AppendBytes mustn't modify the row; got %q", rawRow)
+	}
+	if s := r.String(); s != "x" {
+		t.Fatalf("unexpected string: %q. Expecting %q", s, "x")
+	}
+	if dst := r.AppendBytes(dst); string(dst) != "prefix:a\tb\\\x00" {
+		t.Fatalf("dst must remain unchanged on error; got %q", dst)
+	}
+	if err := r.Error(); !errors.Is(err, ErrNoMoreColumns) {
+		t.Fatalf("expecting ErrNoMoreColumns; got %v", err)
+	}
+}
+
+func TestReaderAppendBytesNoAllocs(t *testing.T) {
+	data := []byte("foo\\tbar\\\\baz\\n\t123\n")
+	br := bytes.NewReader(data)
+	r := New(br)
+	dst := make([]byte, 0, 64)
+	n := testing.AllocsPerRun(100, func() {
+		br.Reset(data)
+		r.Reset(br)
+		r.Next()
+		dst = r.AppendBytes(dst[:0])
+		dst = r.AppendBytes(dst)
+	})
+	if n != 0 {
+		t.Fatalf("unexpected number of allocations: %v. Expecting 0", n)
+	}
+	if string(dst) != "foo\tbar\\baz\n123" {
+		t.Fatalf("unexpected result: %q. Expecting %q", dst, "foo\tbar\\baz\n123")
+	}
+}
+
+func TestReaderSkipInvalidRows(t *testing.T) {
+	b := bytes.NewBufferString("1\tfoo\nbar\t2\n3\tbaz\n\n4\tqux\n")
+	r := New(b)
+	r.SkipInvalidRows = true
+
+	var ns []int
+	var ss []string
+	for r.Next() {
+		n := r.Int()
+		s := r.String()
+		if r.Error() != nil {
+			continue
+		}
+		ns = append(ns, n)
+		ss = append(ss, s)
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if fmt.Sprintf("%v %q", ns, ss) != `[1 3 4] ["foo" "baz" "qux"]` {
+		t.Fatalf("unexpected values read: %v %q", ns, ss)
+	}
+
+	if n := r.SkippedRows(); n != 2 {
+		t.Fatalf("unexpected number of skipped rows: %d. Expecting 2", n)
+	}
+	errs := r.RowErrors()
+	if len(errs) != 2 {
+		t.Fatalf("unexpected number of row errors: %d. Expecting 2", len(errs))
+	}
+	for i, substr := range []string{"cannot parse `int` at row #2, col #1", "cannot parse `int` at row #4, col #1"} {
+		if errS := errs[i].Error(); !strings.Contains(errS, substr) {
+			t.Fatalf("unexpected error #%d: %s. Must contain %q", i, errS, substr)
+		}
+	}
+
+	// Make sure Reset clears errors.
+	r.Reset(bytes.NewBufferString("1\n"))
+	if r.SkippedRows() != 0 {
+		t.Fatalf("unexpected number of skipped rows after Reset: %d", r.SkippedRows())
+	}
+	if len(r.RowErrors()) != 0 {
+		t.Fatalf("unexpected row errors after Reset: %v", r.RowErrors())
+	}
+}
+
+func TestReaderSkipInvalidRowsUnreadCols(t *testing.T) {
+	b := bytes.NewBufferString("1\t2\t3\n4\n")
+	r := New(b)
+	r.SkipInvalidRows = true
+	if !r.Next() {
+		t.Fatalf("Next must return true")
+	}
+	if n := r.Int(); n != 1 {
+		t.Fatalf("unexpected int: %d. Expecting 1", n)
+	}
+
+	// The row has been already read by the caller, so it mustn't be skipped.
+	if r.Next() {
+		t.Fatalf("Next must return false, because the previous row has unread columns")
+	}
+	if err := r.Error(); !errors.Is(err, ErrUnreadColumns) {
+		t.Fatalf("expecting ErrUnreadColumns; got %v", err)
+	}
+	if n := r.SkippedRows(); n != 0 {
+		t.Fatalf("unexpected number of skipped rows: %d. Expecting 0", n)
+	}
+	if errs := r.RowErrors(); len(errs) != 0 {
+		t.Fatalf("unexpected row errors: %v", errs)
+	}
+}
+
+func TestReaderRowErrorHandler(t *testing.T) {
+	b := bytes.NewBufferString("a\nb\n1\nc\n")
+	r := New(b)
+	r.SkipInvalidRows = true
+	var errs []error
+	r.RowErrorHandler = func(err error) {
+		errs = append(errs, err)
+	}
+	var ns []int
+	for r.Next() {
+		n := r.Int()
+		if r.Error() == nil {
+			ns = append(ns, n)
+		}
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(ns) != 1 || ns[0] != 1 {
+		t.Fatalf("unexpected values read: %v. Expecting [1]", ns)
+	}
+	if len(errs) != 3 {
+		t.Fatalf("unexpected number of errors passed to RowErrorHandler: %d. Expecting 3", len(errs))
+	}
+	if len(r.RowErrors()) != 0 {
+		t.Fatalf("RowErrors must be empty when RowErrorHandler is set; got %v", r.RowErrors())
+	}
+}
+
+func TestReaderMaxRowErrors(t *testing.T) {
+	b := bytes.NewBufferString(strings.Repeat("foo\n", 10))
+	r := New(b)
+	r.SkipInvalidRows = true
+	r.MaxRowErrors = 3
+	for r.Next() {
+		r.Int()
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n := len(r.RowErrors()); n != 3 {
+		t.Fatalf("unexpected number of collected errors: %d. Expecting 3", n)
+	}
+	if n := r.SkippedRows(); n != 10 {
+		t.Fatalf("unexpected number of skipped rows: %d. Expecting 10", n)
+	}
+}
+
+func TestReaderRejectedRowsWriter(t *testing.T) {
+	src := "1\tfoo\\tbar\nx\\ty\tbaz\n2\ta\\nb\t3\n4\tqux\n"
+	r := New(bytes.NewBufferString(src))
+	r.SkipInvalidRows = true
+	r.FixedColsCount = true
+	var rejected bytes.Buffer
+	r.RejectedRowsWriter = &rejected
+
+	var ss []string
+	for r.Next() {
+		r.Int()
+		s := r.String()
+		if r.Error() == nil {
+			ss = append(ss, s)
+		}
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if fmt.Sprintf("%q", ss) != `["foo\tbar" "qux"]` {
+		t.Fatalf("unexpected values read: %q", ss)
+	}
+
+	// Rejected rows must be written without unescaping.
+	// The first column must contain the error.
+	rr := New(&rejected)
+	var rows []string
+	var errs []string
+	for rr.Next() {
+		errs = append(errs, rr.String())
+		var cols []string
+		for rr.HasCols() {
+			cols = append(cols, string(rr.RawBytes()))
+		}
+		rows = append(rows, strings.Join(cols, "\t"))
+	}
+	if err := rr.Error(); err != nil {
+		t.Fatalf("unexpected error when reading rejected rows: %s", err)
+	}
+	expectedRows := []string{"x\\ty\tbaz", "2\ta\\nb\t3"}
+	if fmt.Sprintf("%q", rows) != fmt.Sprintf("%q", expectedRows) {
+		t.Fatalf("unexpected rejected rows: %q. Expecting %q", rows, expectedRows)
+	}
+	if !strings.Contains(errs[0], "cannot parse `int` at row #2, col #1") {
+		t.Fatalf("unexpected error for the first rejected row: %q", errs[0])
+	}
+	if !strings.Contains(errs[1], "unexpected number of columns") {
+		t.Fatalf("unexpected error for the second rejected row: %q", errs[1])
+	}
+}
+
+func TestReaderRejectedRowsWriterUnreadCols(t *testing.T) {
+	r := New(bytes.NewBufferString("1\t2\t3\n4\n"))
+	r.SkipInvalidRows = true
+	var rejected bytes.Buffer
+	r.RejectedRowsWriter = &rejected
+
+	r.Next()
+	if n := r.Int(); n != 1 {
+		t.Fatalf("unexpected int: %d. Expecting 1", n)
+	}
+	if r.Next() {
+		t.Fatalf("Next must return false, because the previous row has unread columns")
+	}
+
+	// The row has been accepted by the caller, so it mustn't be rejected.
+	if rejected.Len() > 0 {
+		t.Fatalf("unexpected rejected rows: %q", rejected.String())
+	}
+}
+
+func TestReaderUnescapeChunked(t *testing.T) {
+	for chunkSize := 1; chunkSize <= 20; chunkSize++ {
+		testReaderUnescapeChunked(t, chunkSize, "a\\tb\tfoobarbaz\tc\n", []string{"a\tb", "foobarbaz", "c"})
+		testReaderUnescapeChunked(t, chunkSize, "foobarbaz\tx\\\\y\\n\tqwerty\n", []string{"foobarbaz", "x\\y\n", "qwerty"})
+		testReaderUnescapeChunked(t, chunkSize, "foo\nbar\\0\tbaz\n", []string{"foo"}, []string{"bar\x00", "baz"})
+	}
+
+	// The escaped column is located in the first read buffer,
+	// while the end of the row is located in the second read buffer.
+	long := strings.Repeat("x", 2*len(Reader{}.rBuf))
+	testReaderUnescapeChunked(t, len(long), "a\\nb\t"+long+"\n", []string{"a\nb", long})
+	testReaderUnescapeChunked(t, len(long), "foo\n"+long+"\ta\\nb\n", []string{"foo"}, []string{long, "a\nb"})
+}
+
+func testReaderUnescapeChunked(t *testing.T, chunkSize int, s string, expected ...[]string) {
+	t.Helper()
+
+	b := &chunkedSource{
+		s:         []byte(s),
+		chunkSize: chunkSize,
+	}
+	r := New(b)
+	testReaderMultiRowsCols(t, r, expected)
+	if r.Next() {
+		t.Fatalf("Next must return false")
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+// chunkedSource returns data by chunks with the given size.
+type chunkedSource struct {
+	s         []byte
+	chunkSize int
+}
+
+func (cs *chunkedSource) Read(p []byte) (int, error) {
+	if len(cs.s) == 0 {
+		return 0, io.EOF
+	}
+	chunkSize := cs.chunkSize
+	if chunkSize > len(cs.s) {
+		chunkSize = len(cs.s)
+	}
+	n := copy(p, cs.s[:chunkSize])
+	cs.s = cs.s[n:]
+	return n, nil
+}
+
+func TestReaderPeekBytes(t *testing.T) {
+	b := bytes.NewBufferString("\\N\t42\tfoo\\tbar\n")
+	r := New(b)
+	if bb := r.PeekBytes(); bb != nil {
+		t.Fatalf("PeekBytes must return nil before Next; got %q", bb)
+	}
+	if !r.Next() {
+		t.Fatalf("Next must return true")
+	}
+
+	for i := 0; i < 3; i++ {
+		bb := r.PeekBytes()
+		if string(bb) != `\N` {
+			t.Fatalf("unexpected peeked bytes: %q. Expecting %q", bb, `\N`)
+		}
+	}
+	r.SkipCol()
+	if bb := r.PeekBytes(); string(bb) != "42" {
+		t.Fatalf("unexpected peeked bytes: %q. Expecting %q", bb, "42")
+	}
+	if n := r.Int(); n != 42 {
+		t.Fatalf("unexpected int: %d. Expecting 42", n)
+	}
+	if bb := r.PeekBytes(); string(bb) != `foo\tbar` {
+		t.Fatalf("unexpected peeked bytes: %q. Expecting %q", bb, `foo\tbar`)
+	}
+	if s := r.String(); s != "foo\tbar" {
+		t.Fatalf("unexpected string: %q. Expecting %q", s, "foo\tbar")
+	}
+	if bb := r.PeekBytes(); bb != nil {
+		t.Fatalf("PeekBytes must return nil at the end of row; got %q", bb)
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if r.Next() {
+		t.Fatalf("Next must return false")
+	}
+}
+
+func TestReaderRawBytes(t *testing.T) {
+	b := bytes.NewBufferString("foo\\tbar\t\\N\n")
+	r := New(b)
+	r.Next()
+	if bb := r.RawBytes(); string(bb) != `foo\tbar` {
+		t.Fatalf("unexpected raw bytes: %q. Expecting %q", bb, `foo\tbar`)
+	}
+	if bb := r.RawBytes(); string(bb) != `\N` {
+		t.Fatalf("unexpected raw bytes: %q. Expecting %q", bb, `\N`)
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if r.HasCols() {
+		t.Fatalf("HasCols must return false")
+	}
+	if bb := r.RawBytes(); bb != nil {
+		t.Fatalf("unexpected non-nil raw bytes: %q", bb)
+	}
+	if err := r.Error(); err == nil || !strings.Contains(err.Error(), "no more columns") {
+		t.Fatalf("unexpected error: %v. Must contain %q", err, "no more columns")
+	}
+}
+
+func TestReaderSkipCols(t *testing.T) {
+	b := bytes.NewBufferString("1\t2\t3\t4\t5\n6\t7\n")
+	r := New(b)
+	r.Next()
+	r.SkipCols(0)
+	r.SkipCols(2)
+	if n := r.Int(); n != 3 {
+		t.Fatalf("unexpected int: %d. Expecting 3", n)
+	}
+	r.SkipCols(2)
+	if r.HasCols() {
+		t.Fatalf("HasCols must return false")
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Attempt to skip more columns than the row contains.
+	r.Next()
+	r.SkipCols(3)
+	err := r.Error()
+	if err == nil {
+		t.Fatalf("expecting non-nil error")
+	}
+	if errS := err.Error(); !strings.Contains(errS, "cannot skip column at row #2, col #3") {
+		t.Fatalf("unexpected error: %s. Must contain %q", errS, "cannot skip column at row #2, col #3")
+	}
+}
+
+func TestReaderCol(t *testing.T) {
+	var ss []string
+	for i := 0; i < 250; i++ {
+		ss = append(ss, fmt.Sprintf("col%d", i))
+	}
+	ss[150] = `foo\tbar`
+	row := strings.Join(ss, "\t") + "\n"
+	b := bytes.NewBufferString(row + row)
+	r := New(b)
+
+	for i := 0; i < 2; i++ {
+		if !r.Next() {
+			t.Fatalf("Next must return true")
+		}
+		if i == 1 {
+			// Sequential reading before random access must work.
+			if s := r.String(); s != "col0" {
+				t.Fatalf("unexpected string: %q. Expecting %q", s, "col0")
+			}
+		}
+		if bb := r.Col(201); string(bb) != "col201" {
+			t.Fatalf("unexpected col #201: %q. Expecting %q", bb, "col201")
+		}
+		if bb := r.Col(150); string(bb) != "foo\tbar" {
+			t.Fatalf("unexpected col #150: %q. Expecting %q", bb, "foo\tbar")
+		}
+		if bb := r.Col(3); string(bb) != "col3" {
+			t.Fatalf("unexpected col #3: %q. Expecting %q", bb, "col3")
+		}
+		if bb := r.Col(150); string(bb) != "foo\tbar" {
+			t.Fatalf("unexpected col #150 on the second access: %q. Expecting %q", bb, "foo\tbar")
+		}
+		if bb := r.Col(249); string(bb) != "col249" {
+			t.Fatalf("unexpected col #249: %q. Expecting %q", bb, "col249")
+		}
+		if r.HasCols() {
+			t.Fatalf("HasCols must return false after Col call")
+		}
+		if err := r.Error(); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	// Access to missing column must fail.
+	r.Col(250)
+	err := r.Error()
+	if err == nil {
+		t.Fatalf("expecting non-nil error")
+	}
+	if errS := err.Error(); !strings.Contains(errS, "no more columns") {
+		t.Fatalf("unexpected error: %s. Must contain %q", errS, "no more columns")
+	}
+
+	// Out of range indexes must fail without panic.
+	for _, i := range []int{-1, math.MaxInt, math.MinInt} {
+		r.ResetError()
+		if bb := r.Col(i); bb != nil {
+			t.Fatalf("unexpected non-nil value for Col(%d): %q", i, bb)
+		}
+		if err := r.Error(); !errors.Is(err, ErrNoMoreColumns) {
+			t.Fatalf("expecting ErrNoMoreColumns for Col(%d); got %v", i, err)
+		}
+	}
+}
+
+func TestReaderColNoAllocs(t *testing.T) {
+	row := []byte(strings.Repeat("foo\tbar\\n\t", 100) + "baz\n")
+	b := bytes.NewReader(row)
+	r := New(b)
+	// Warm up buffers.
+	r.Next()
+	r.Col(100)
+	r.Col(151)
+	n := testing.AllocsPerRun(100, func() {
+		b.Reset(row)
+		r.Reset(b)
+		if !r.Next() {
+			panic("Next must return true")
+		}
+		if string(r.Col(100)) != "foo" {
+			panic("unexpected col #100")
+		}
+		if string(r.Col(151)) != "bar\n" {
+			panic("unexpected col #151")
+		}
+	})
+	if n != 0 {
+		t.Fatalf("unexpected number of allocations: %v. Expecting 0", n)
+	}
+}
+
+func TestReaderProjection(t *testing.T) {
+	b := bytes.NewBufferString("foo\\tbar\t1\tbaz\tq\t2\n\n\\\\\t3\n\\\\\t4\tx\ty\t5\tz\n")
+	r := New(b)
+	r.Projection = []int{4, 0}
+
+	if !r.Next() {
+		t.Fatalf("Next must return true")
+	}
+	if bb := r.PeekBytes(); string(bb) != "2" {
+		t.Fatalf("unexpected peeked bytes: %q. Expecting %q", bb, "2")
+	}
+	if n := r.Int(); n != 2 {
+		t.Fatalf("unexpected int: %d. Expecting 2", n)
+	}
+	if s := r.String(); s != "foo\tbar" {
+		t.Fatalf("unexpected string: %q. Expecting %q", s, "foo\tbar")
+	}
+	if r.HasCols() {
+		t.Fatalf("HasCols must return false")
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Empty row has no columns.
+	if !r.Next() {
+		t.Fatalf("Next must return true")
+	}
+	if r.HasCols() {
+		t.Fatalf("HasCols must return false on empty row")
+	}
+
+	// Too short row.
+	if !r.Next() {
+		t.Fatalf("Next must return true")
+	}
+	if r.HasCols() {
+		t.Fatalf("HasCols must return false, since the projected column #5 is missing")
+	}
+	r.Int()
+	err := r.Error()
+	if err == nil {
+		t.Fatalf("expecting non-nil error")
+	}
+	if errS := err.Error(); !strings.Contains(errS, "cannot read `int` at row #3, col #5") || !strings.Contains(errS, "no more columns") {
+		t.Fatalf("unexpected error: %s", errS)
+	}
+	r.ResetError()
+	r.SkipCols(1)
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Unread projected columns.
+	if !r.Next() {
+		t.Fatalf("Next must return true")
+	}
+	r.SkipCol()
+	if r.Next() {
+		t.Fatalf("Next must return false")
+	}
+	err = r.Error()
+	if err == nil {
+		t.Fatalf("expecting non-nil error")
+	}
+	if errS := err.Error(); !strings.Contains(errS, `contains unread columns: "\\\\\t4\tx\ty\t5\tz"`) {
+		t.Fatalf("unexpected error: %s", errS)
+	}
+}
+
+func TestReaderProjectionRaggedRows(t *testing.T) {
+	b := bytes.NewBufferString("x\na\tb\tc\td\te\ny\tz\n")
+	r := New(b)
+	r.Projection = []int{0, 3}
+
+	var rows [][]string
+	for r.Next() {
+		var cols []string
+		for r.HasCols() {
+			cols = append(cols, r.String())
+		}
+		rows = append(rows, cols)
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if s := fmt.Sprintf("%q", rows); s != `[["x"] ["a" "d"] ["y"]]` {
+		t.Fatalf("unexpected rows: %s", s)
+	}
+
+	// Reading only existing projected columns mustn't result in ErrUnreadColumns.
+	r.Reset(bytes.NewBufferString("x\ny\n"))
+	for r.Next() {
+		r.SkipCol()
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestReaderProjectionHugeIndex(t *testing.T) {
+	b := bytes.NewBufferString("foo\tbar\n")
+	r := New(b)
+	r.Projection = []int{math.MaxInt, 1}
+	if !r.Next() {
+		t.Fatalf("Next must return true")
+	}
+	if r.HasCols() {
+		t.Fatalf("HasCols must return false")
+	}
+	if bb := r.PeekBytes(); bb != nil {
+		t.Fatalf("unexpected non-nil peeked bytes: %q", bb)
+	}
+	r.Bytes()
+	if err := r.Error(); !errors.Is(err, ErrNoMoreColumns) {
+		t.Fatalf("expecting ErrNoMoreColumns; got %v", err)
+	}
+}
+
+func TestReaderProjectionNames(t *testing.T) {
+	b := bytes.NewBufferString("id\tna\\tme\tvalue\n1\tfoo\t1.5\n2\tbar\t2.5\n")
+	r := New(b)
+	r.HasHeader = true
+	r.ProjectionNames = []string{"value", "na\tme"}
+
+	var fs []float64
+	var ss []string
+	for r.Next() {
+		fs = append(fs, r.Float64())
+		ss = append(ss, r.String())
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if fmt.Sprintf("%v %q", fs, ss) != `[1.5 2.5] ["foo" "bar"]` {
+		t.Fatalf("unexpected values read: %v %q", fs, ss)
+	}
+	header := r.Header()
+	if fmt.Sprintf("%q", header) != `["id" "na\tme" "value"]` {
+		t.Fatalf("unexpected header: %q", header)
+	}
+
+	// Missing column name.
+	r.Reset(bytes.NewBufferString("id\tname\n1\tfoo\n"))
+	r.ProjectionNames = []string{"id", "missing"}
+	if r.Next() {
+		t.Fatalf("Next must return false")
+	}
+	err := r.Error()
+	if err == nil {
+		t.Fatalf("expecting non-nil error")
+	}
+	if errS := err.Error(); !strings.Contains(errS, `cannot find column "missing"`) {
+		t.Fatalf("unexpected error: %s", errS)
+	}
+}
+
+func TestReaderHeader(t *testing.T) {
+	b := bytes.NewBufferString("a\tb\n1\t2\n")
+	r := New(b)
+	r.HasHeader = true
+	if !r.Next() {
+		t.Fatalf("Next must return true")
+	}
+	if fmt.Sprintf("%q", r.Header()) != `["a" "b"]` {
+		t.Fatalf("unexpected header: %q", r.Header())
+	}
+	if n := r.Int(); n != 1 {
+		t.Fatalf("unexpected int: %d. Expecting 1", n)
+	}
+	if n := r.Int(); n != 2 {
+		t.Fatalf("unexpected int: %d. Expecting 2", n)
+	}
+	if r.Next() {
+		t.Fatalf("Next must return false")
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	// Header without data rows.
+	r.Reset(bytes.NewBufferString("a\tb\n"))
+	if r.Next() {
+		t.Fatalf("Next must return false")
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if fmt.Sprintf("%q", r.Header()) != `["a" "b"]` {
+		t.Fatalf("unexpected header: %q", r.Header())
+	}
+}
+
+func TestReaderColsCount(t *testing.T) {
+	b := bytes.NewBufferString("1\t2\t3\n4\t5\n")
+	r := New(b)
+	r.ColsCount = 3
+	if !r.Next() {
+		t.Fatalf("Next must return true")
+	}
+	r.SkipCols(3)
+	if r.Next() {
+		t.Fatalf("Next must return false")
+	}
+	err := r.Error()
+	if !errors.Is(err, ErrColsCount) {
+		t.Fatalf("expecting ErrColsCount; got %v", err)
+	}
+	var pe *ParseError
+	if !errors.As(err, &pe) {
+		t.Fatalf("expecting ParseError; got %T", err)
+	}
+	if pe.Row != 2 {
+		t.Fatalf("unexpected row: %d. Expecting 2", pe.Row)
+	}
+	expectedS := `row #2 "4\t5": unexpected number of columns: 2; expecting 3`
+	if errS := err.Error(); errS != expectedS {
+		t.Fatalf("unexpected error: %q. Expecting %q", errS, expectedS)
+	}
+}
+
+func TestReaderFixedColsCount(t *testing.T) {
+	// The number of columns is obtained from the first row.
+	b := bytes.NewBufferString("1\t2\n3\t4\t5\n6\t7\n\n8\n9\t10\n")
+	r := New(b)
+	r.FixedColsCount = true
+	r.SkipInvalidRows = true
+	var ns []int
+	for r.Next() {
+		ns = append(ns, r.Int(), r.Int())
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if fmt.Sprintf("%v", ns) != "[1 2 6 7 9 10]" {
+		t.Fatalf("unexpected values read: %v", ns)
+	}
+	if n := r.SkippedRows(); n != 3 {
+		t.Fatalf("unexpected number of skipped rows: %d. Expecting 3", n)
+	}
+	for _, err := range r.RowErrors() {
+		if !errors.Is(err, ErrColsCount) {
+			t.Fatalf("expecting ErrColsCount; got %v", err)
+		}
+	}
+
+	// The number of columns is obtained from the header.
+	r = New(bytes.NewBufferString("a\tb\tc\n1\t2\n"))
+	r.HasHeader = true
+	r.FixedColsCount = true
+	if r.Next() {
+		t.Fatalf("Next must return false")
+	}
+	if err := r.Error(); !errors.Is(err, ErrColsCount) {
+		t.Fatalf("expecting ErrColsCount; got %v", err)
+	}
+
+	// The header must contain ColsCount columns.
+	r = New(bytes.NewBufferString("a\tb\tc\n1\t2\n"))
+	r.HasHeader = true
+	r.ColsCount = 2
+	if r.Next() {
+		t.Fatalf("Next must return false")
+	}
+	if err := r.Error(); !errors.Is(err, ErrColsCount) || !strings.Contains(err.Error(), "in the header") {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestReaderSkipRestCols(t *testing.T) {
+	b := bytes.NewBufferString("1\tfoo\tbar\n2\n3\tbaz\n")
+	r := New(b)
+	var ns []int
+	for r.Next() {
+		ns = append(ns, r.Int())
+		r.SkipRestCols()
+		if r.HasCols() {
+			t.Fatalf("HasCols must return false after SkipRestCols")
+		}
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if fmt.Sprintf("%v", ns) != "[1 2 3]" {
+		t.Fatalf("unexpected values read: %v", ns)
+	}
+}
+
+func TestReaderIgnoreUnreadCols(t *testing.T) {
+	b := bytes.NewBufferString("1\tfoo\tbar\n2\n3\tbaz\n")
+	r := New(b)
+	r.IgnoreUnreadCols = true
+	var ns []int
+	for r.Next() {
+		ns = append(ns, r.Int())
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if fmt.Sprintf("%v", ns) != "[1 2 3]" {
+		t.Fatalf("unexpected values read: %v", ns)
+	}
+}
+
+func TestReaderEmptyAsDefault(t *testing.T) {
+	b := bytes.NewBufferString(strings.Repeat("\t", 13) + "\n")
+	r := New(b)
+	r.EmptyAsDefault = true
+	if !r.Next() {
+		t.Fatalf("Next must return true")
+	}
+	values := []interface{}{
+		r.Int(), r.Uint(), r.Int32(), r.Uint32(), r.Int16(), r.Uint16(), r.Int8(),
+		r.Uint8(), r.Int64(), r.Uint64(), r.Float32(), r.Float64(),
+		r.Date().IsZero(), r.DateTime().IsZero(),
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if s := fmt.Sprintf("%v", values); s != "[0 0 0 0 0 0 0 0 0 0 0 0 true true]" {
+		t.Fatalf("unexpected values: %s", s)
+	}
+	if r.HasCols() {
+		t.Fatalf("HasCols must return false")
+	}
+
+	// Empty columns must result in error by default.
+	r.Reset(bytes.NewBufferString("\n"))
+	r.EmptyAsDefault = false
+	r.Next()
+	r.Int()
+	if err := r.Error(); err == nil {
+		t.Fatalf("expecting non-nil error")
+	}
+}
+
+type testTextUnmarshaler struct {
+	s string
+}
+
+func (u *testTextUnmarshaler) UnmarshalText(b []byte) error {
+	if len(b) == 0 {
+		return fmt.Errorf("empty text")
+	}
+	u.s = strings.ToUpper(string(b))
+	return nil
+}
+
+func TestReaderText(t *testing.T) {
+	b := bytes.NewBufferString("foo\\tbar\t\n")
+	r := New(b)
+	r.Next()
+	var u testTextUnmarshaler
+	r.Text(&u)
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if u.s != "FOO\tBAR" {
+		t.Fatalf("unexpected value: %q. Expecting %q", u.s, "FOO\tBAR")
+	}
+	r.Text(&u)
+	err := r.Error()
+	if err == nil {
+		t.Fatalf("expecting non-nil error")
+	}
+	expectedS := "cannot parse `tsvreader.testTextUnmarshaler` at row #1, col #2"
+	if errS := err.Error(); !strings.Contains(errS, expectedS) {
+		t.Fatalf("unexpected error: %s. Must contain %q", errS, expectedS)
+	}
+}
+
+func TestReaderTextEmptyAsDefaultReuse(t *testing.T) {
+	b := bytes.NewBufferString("foo\n\n")
+	r := New(b)
+	r.EmptyAsDefault = true
+	var u testTextUnmarshaler
+	var ss []string
+	for r.Next() {
+		r.Text(&u)
+		ss = append(ss, u.s)
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if s := fmt.Sprintf("%q", ss); s != `["FOO" ""]` {
+		t.Fatalf("unexpected values: %s. Expecting %s", s, `["FOO" ""]`)
+	}
+}
+
+func TestReaderTextEmptyAsDefault(t *testing.T) {
+	b := bytes.NewBufferString("\tfoo\n")
+	r := New(b)
+	r.EmptyAsDefault = true
+	r.Next()
+	var u testTextUnmarshaler
+	r.Text(&u)
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if u.s != "" {
+		t.Fatalf("unexpected non-empty value: %q", u.s)
+	}
+	r.Text(&u)
+	if u.s != "FOO" {
+		t.Fatalf("unexpected value: %q. Expecting %q", u.s, "FOO")
+	}
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestReaderStrictDatesSuccess(t *testing.T) {
+	testReaderStrictDateSuccess(t, "0000-00-00")
+	testReaderStrictDateSuccess(t, "2017-10-13")
+	testReaderStrictDateSuccess(t, "2024-02-29")
+	testReaderStrictDateSuccess(t, "2000-02-29")
+	testReaderStrictDateSuccess(t, "2023-12-31")
+
+	testReaderStrictDateTimeSuccess(t, "0000-00-00 00:00:00")
+	testReaderStrictDateTimeSuccess(t, "2024-02-29 23:59:59")
+	testReaderStrictDateTimeSuccess(t, "2017-10-13 00:00:00")
+}
+
+func testReaderStrictDateSuccess(t *testing.T, date string) {
+	t.Helper()
+
+	r := New(bytes.NewBufferString(date + "\n" + date + "\n"))
+	r.StrictDates = true
+	r.Next()
+	dt := r.Date()
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error on date %q: %s", date, err)
+	}
+	if s := dt.Format("2006-01-02"); s != date && !(date == "0000-00-00" && dt.IsZero()) {
+		t.Fatalf("unexpected date: %q. Expecting %q", s, date)
+	}
+	r.Next()
+	d := r.CivilDate()
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error on date %q: %s", date, err)
+	}
+	if s := d.String(); s != date {
+		t.Fatalf("unexpected date: %q. Expecting %q", s, date)
+	}
+}
+
+func testReaderStrictDateTimeSuccess(t *testing.T, datetime string) {
+	t.Helper()
+
+	r := New(bytes.NewBufferString(datetime + "\n"))
+	r.StrictDates = true
+	r.Next()
+	dt := r.DateTime()
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error on datetime %q: %s", datetime, err)
+	}
+	if s := dt.Format("2006-01-02 15:04:05"); s != datetime && !(datetime == "0000-00-00 00:00:00" && dt.IsZero()) {
+		t.Fatalf("unexpected datetime: %q. Expecting %q", s, datetime)
+	}
+}
+
+func TestReaderStrictDatesFailure(t *testing.T) {
+	testReaderStrictDateFailure(t, "2023-02-31")
+	testReaderStrictDateFailure(t, "2023-02-29")
+	testReaderStrictDateFailure(t, "1900-02-29")
+	testReaderStrictDateFailure(t, "2023-04-31")
+	testReaderStrictDateFailure(t, "2023-13-01")
+	testReaderStrictDateFailure(t, "2023-00-01")
+	testReaderStrictDateFailure(t, "2023-01-00")
+	testReaderStrictDateFailure(t, "2023-+1-01")
+	testReaderStrictDateFailure(t, "+023-01-01")
+
+	testReaderStrictDateTimeFailure(t, "2023-02-31 00:00:00")
+	testReaderStrictDateTimeFailure(t, "2023-01-01 25:00:00")
+	testReaderStrictDateTimeFailure(t, "2023-01-01 23:60:00")
+	testReaderStrictDateTimeFailure(t, "2023-01-01 23:00:60")
+	testReaderStrictDateTimeFailure(t, "2023-01-01 25:61:61")
+	testReaderStrictDateTimeFailure(t, "2023-01-01 -1:00:00")
+	testReaderStrictDateTimeFailure(t, "2023-01-01 +1:00:00")
+}
+
+func testReaderStrictDateFailure(t *testing.T, date string) {
+	t.Helper()
+
+	r := New(bytes.NewBufferString(date + "\n" + date + "\n"))
+	r.StrictDates = true
+	r.Next()
+	dt := r.Date()
+	if !dt.IsZero() {
+		t.Fatalf("unexpected non-zero date when parsing %q: %s", date, dt)
+	}
+	err := r.Error()
+	if err == nil {
+		t.Fatalf("expecting non-nil error when parsing %q", date)
+	}
+	if errS := err.Error(); !strings.Contains(errS, "cannot parse `date`") {
+		t.Fatalf("unexpected error: %s. Must contain %q", errS, "cannot parse `date`")
+	}
+
+	r.ResetError()
+	r.Next()
+	d := r.CivilDate()
+	if !d.IsZero() {
+		t.Fatalf("unexpected non-zero date when parsing %q: %s", date, d)
+	}
+	if r.Error() == nil {
+		t.Fatalf("expecting non-nil error when parsing %q", date)
+	}
+}
+
+func testReaderStrictDateTimeFailure(t *testing.T, datetime string) {
+	t.Helper()
+
+	r := New(bytes.NewBufferString(datetime + "\n"))
+	r.StrictDates = true
+	r.Next()
+	dt := r.DateTime()
+	if !dt.IsZero() {
+		t.Fatalf("unexpected non-zero datetime when parsing %q: %s", datetime, dt)
+	}
+	err := r.Error()
+	if err == nil {
+		t.Fatalf("expecting non-nil error when parsing %q", datetime)
+	}
+	if errS := err.Error(); !strings.Contains(errS, "cannot parse `datetime`") {
+		t.Fatalf("unexpected error: %s. Must contain %q", errS, "cannot parse `datetime`")
+	}
+}
@@ -153,3 +153,43 @@ func createUintTSV(rows, cols int) []byte {
 	}
 	return bb.Bytes()
 }
+
+func BenchmarkReaderSkipCols(b *testing.B) {
+	const rows, cols = 1000, 200
+	bb := createIntTSV(rows, cols)
+	b.Run("SkipCol", func(b *testing.B) {
+		benchmarkReaderSkipCols(b, bb, rows, func(r *Reader, n int) {
+			for i := 0; i < n; i++ {
+				r.SkipCol()
+			}
+		})
+	})
+	b.Run("SkipCols", func(b *testing.B) {
+		benchmarkReaderSkipCols(b, bb, rows, (*Reader).SkipCols)
+	})
+}
+
+func benchmarkReaderSkipCols(b *testing.B, bb []byte, rows int, skipCols func(r *Reader, n int)) {
+	br := bytes.NewReader(bb)
+	r := New(br)
+	r.IgnoreUnreadCols = true
+	b.ReportAllocs()
+	b.SetBytes(int64(len(bb)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < rows; j++ {
+			if !r.Next() {
+				b.Fatalf("Reader.Next must return true on row #%d", j+1)
+			}
+			skipCols(r, 150)
+			if r.Int() == 0 {
+				b.Fatalf("unexpected zero int on row #%d", j+1)
+			}
+		}
+		if err := r.Error(); err != nil {
+			b.Fatalf("unexpected error: %s", err)
+		}
+		br.Reset(bb)
+		r.Reset(br)
+	}
+}
@@ -0,0 +1,68 @@
+package tsvreader
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UnixTime returns the next unix timestamp column value in the given unit from the current row.
+//
+// The column must contain the number of units since the epoch, where unit
+// is one of time.Second, time.Millisecond, time.Microsecond or
+// time.Nanosecond. Fractional forms such as 1700000000.123 are accepted;
+// digits finer than a nanosecond are ignored.
+//
+// The returned time is always in UTC.
+func (tr *Reader) UnixTime(unit time.Duration) time.Time {
+	if tr.err != nil {
+		return zeroTime
+	}
+	b, err := tr.nextCol()
+	if err != nil {
+		tr.setColError("cannot read", "unixtime", nil, err)
+		return zeroTime
+	}
+	if len(b) == 0 && tr.EmptyAsDefault {
+		return zeroTime
+	}
+	s := b2s(b)
+
+	t, err := parseUnixTime(s, unit)
+	if err != nil {
+		tr.setColError("cannot parse", "unixtime", b, err)
+		return zeroTime
+	}
+	return t
+}
+
+func parseUnixTime(s string, unit time.Duration) (time.Time, error) {
+	switch unit {
+	case time.Second, time.Millisecond, time.Microsecond, time.Nanosecond:
+	default:
+		return zeroTime, fmt.Errorf("unsupported unix time unit %s", unit)
+	}
+
+	intS, fracS, hasFrac := strings.Cut(s, ".")
+	if hasFrac && (len(fracS) == 0 || !isDigits(fracS)) {
+		return zeroTime, strconv.ErrSyntax
+	}
+
+	n, err := parseInt64(intS)
+	if err != nil {
+		return zeroTime, err
+	}
+
+	unitsPerSec := int64(time.Second / unit)
+	sec := n / unitsPerSec
+	nsec := (n % unitsPerSec) * int64(unit)
+	if hasFrac {
+		fracNsec := int64(parseNanos(fracS)) * int64(unit) / int64(time.Second)
+		if intS[0] == '-' {
+			fracNsec = -fracNsec
+		}
+		nsec += fracNsec
+	}
+	return time.Unix(sec, nsec).UTC(), nil
+}
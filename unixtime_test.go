@@ -0,0 +1,116 @@
+package tsvreader
+
+import (
+	"bytes"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReaderUnixTimeSuccess(t *testing.T) {
+	f := func(unit time.Duration, s string, expected time.Time) {
+		t.Helper()
+
+		r := New(bytes.NewBufferString(s + "\n"))
+		r.Next()
+		tm := r.UnixTime(unit)
+		if err := r.Error(); err != nil {
+			t.Fatalf("unexpected error when parsing %q in %s: %s", s, unit, err)
+		}
+		if !tm.Equal(expected) {
+			t.Fatalf("unexpected time when parsing %q in %s: %s. Expecting %s", s, unit, tm, expected)
+		}
+		if tm.Location() != time.UTC {
+			t.Fatalf("unexpected location when parsing %q in %s: %s. Expecting UTC", s, unit, tm.Location())
+		}
+	}
+
+	f(time.Second, "0", time.Unix(0, 0))
+	f(time.Second, "1700000000", time.Date(2023, 11, 14, 22, 13, 20, 0, time.UTC))
+	f(time.Second, "1700000000.123", time.Date(2023, 11, 14, 22, 13, 20, 123e6, time.UTC))
+	f(time.Second, "1700000000.1234567891", time.Date(2023, 11, 14, 22, 13, 20, 123456789, time.UTC))
+	f(time.Second, "-1", time.Date(1969, 12, 31, 23, 59, 59, 0, time.UTC))
+	f(time.Second, "-1.5", time.Date(1969, 12, 31, 23, 59, 58, 5e8, time.UTC))
+	f(time.Millisecond, "1700000000123", time.Date(2023, 11, 14, 22, 13, 20, 123e6, time.UTC))
+	f(time.Millisecond, "1700000000123.5", time.Date(2023, 11, 14, 22, 13, 20, 123500000, time.UTC))
+	f(time.Microsecond, "1700000000123456", time.Date(2023, 11, 14, 22, 13, 20, 123456e3, time.UTC))
+	f(time.Microsecond, "-1500000", time.Date(1969, 12, 31, 23, 59, 58, 5e8, time.UTC))
+	f(time.Nanosecond, "1700000000123456789", time.Date(2023, 11, 14, 22, 13, 20, 123456789, time.UTC))
+	f(time.Nanosecond, "1.9", time.Unix(0, 1))
+}
+
+func TestReaderUnixTimeFailure(t *testing.T) {
+	f := func(unit time.Duration, s string) {
+		t.Helper()
+
+		r := New(bytes.NewBufferString(s + "\n"))
+		r.Next()
+		tm := r.UnixTime(unit)
+		if !tm.IsZero() {
+			t.Fatalf("expecting zero time when parsing %q in %s; got %s", s, unit, tm)
+		}
+		err := r.Error()
+		if err == nil {
+			t.Fatalf("expecting non-nil error when parsing %q in %s", s, unit)
+		}
+		if errS := err.Error(); !strings.Contains(errS, "cannot parse `unixtime`") {
+			t.Fatalf("unexpected error: %s. Must contain %q", errS, "cannot parse `unixtime`")
+		}
+	}
+
+	f(time.Second, "")
+	f(time.Second, "foo")
+	f(time.Second, "1700000000.")
+	f(time.Second, ".5")
+	f(time.Second, "1.5.5")
+	f(time.Second, "1.-5")
+	f(time.Second, "2023-05-01")
+	f(time.Minute, "1700000000")
+	f(0, "1700000000")
+}
+
+func TestReaderUnixTimeOutOfRange(t *testing.T) {
+	r := New(bytes.NewBufferString("99999999999999999999\n"))
+	r.Next()
+	r.UnixTime(time.Millisecond)
+	if err := r.Error(); !errors.Is(err, strconv.ErrRange) {
+		t.Fatalf("expecting strconv.ErrRange; got %v", err)
+	}
+}
+
+func TestReaderUnixTimeE(t *testing.T) {
+	r := New(bytes.NewBufferString("2023-05-01 12:00:00\n"))
+	r.Next()
+	if _, err := r.UnixTimeE(time.Second); err == nil {
+		t.Fatalf("expecting non-nil error")
+	}
+	tm := r.DateTime()
+	if err := r.Error(); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expected := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC); !tm.Equal(expected) {
+		t.Fatalf("unexpected time: %s. Expecting %s", tm, expected)
+	}
+}
+
+func TestReaderUnixTimeNoAllocs(t *testing.T) {
+	data := []byte("1700000000\t1700000000123.456\n")
+	br := bytes.NewReader(data)
+	r := New(br)
+	n := testing.AllocsPerRun(100, func() {
+		br.Reset(data)
+		r.Reset(br)
+		r.Next()
+		if r.UnixTime(time.Second).IsZero() {
+			panic("unexpected zero time")
+		}
+		if r.UnixTime(time.Millisecond).IsZero() {
+			panic("unexpected zero time")
+		}
+	})
+	if n != 0 {
+		t.Fatalf("unexpected number of allocations: %v. Expecting 0", n)
+	}
+}